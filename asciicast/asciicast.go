@@ -0,0 +1,101 @@
+// Package asciicast writes terminal output in the asciicast v2 format used by
+// asciinema, so recorded games can be played in standard asciicast players.
+//
+// See https://docs.asciinema.org/manual/asciicast/v2/ for the format.
+package asciicast
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the version of the asciicast format written.
+const Version = 2
+
+// DefaultWidth is a terminal width wide enough to show a full table.
+const DefaultWidth = 80
+
+// DefaultHeight is a terminal height tall enough to show a full table without scrolling.
+const DefaultHeight = 25
+
+// A Header describes a recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"` // unix time the recording started
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// NewHeader returns a header for a recording with the default size starting now.
+func NewHeader(title string) Header {
+	return Header{
+		Version:   Version,
+		Width:     DefaultWidth,
+		Height:    DefaultHeight,
+		Timestamp: time.Now().Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}
+}
+
+// A Clock returns the time since the start of a recording.
+type Clock = func() time.Duration
+
+// WallClock returns a clock that measures real time from now.
+func WallClock() Clock {
+	start := time.Now()
+	return func() time.Duration {
+		return time.Since(start)
+	}
+}
+
+// A Writer records everything written to it as output events.
+type Writer struct {
+	w       io.Writer
+	clock   Clock
+	pending []byte // the start of a character split across writes
+}
+
+// NewWriter writes the header to w and returns a Writer that timestamps output with the clock.
+func NewWriter(w io.Writer, header Header, clock Clock) (*Writer, error) {
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, clock: clock}, nil
+}
+
+// Write records p as output at the current time on the writer's clock.
+func (w *Writer) Write(p []byte) (int, error) {
+	data := append(w.pending, p...)
+	// hold back an incomplete character at the end so events are always valid UTF-8
+	complete := len(data)
+	for i := len(data) - 1; (i >= 0) && (i >= len(data)-utf8.UTFMax); i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				complete = i
+			}
+			break
+		}
+	}
+	w.pending = append([]byte(nil), data[complete:]...)
+	if complete == 0 {
+		return len(p), nil
+	}
+	seconds := w.clock().Seconds()
+	line, err := json.Marshal([]interface{}{seconds, "o", string(data[:complete])})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(append(line, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,85 @@
+package asciicast_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/asciicast"
+)
+
+// read the header and the output of each event in a recording
+func readCast(t *testing.T, cast []byte) (asciicast.Header, []string) {
+	t.Helper()
+	scanner := bufio.NewScanner(bytes.NewReader(cast))
+	var header asciicast.Header
+	if !scanner.Scan() {
+		t.Fatal("recording is empty")
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatal(err)
+	}
+	outputs := make([]string, 0)
+	for scanner.Scan() {
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if (len(event) != 3) || (event[1] != "o") {
+			t.Fatalf("unexpected event %v", event)
+		}
+		outputs = append(outputs, event[2].(string))
+	}
+	return header, outputs
+}
+
+func TestWriteSplitsOnCharacters(t *testing.T) {
+	text := "a●b╭─╮"
+	for split := 0; split <= len(text); split++ {
+		var b bytes.Buffer
+		w, err := asciicast.NewWriter(&b, asciicast.NewHeader("test"), func() time.Duration {
+			return time.Second
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, part := range []string{text[:split], text[split:]} {
+			if n, err := w.Write([]byte(part)); (err != nil) || (n != len(part)) {
+				t.Fatalf("split at %d: wrote %d of %d bytes: %v", split, n, len(part), err)
+			}
+		}
+		header, outputs := readCast(t, b.Bytes())
+		if (header.Version != asciicast.Version) || (header.Title != "test") {
+			t.Errorf("split at %d: wrote header %+v", split, header)
+		}
+		joined := ""
+		for _, output := range outputs {
+			joined += output
+		}
+		if joined != text {
+			t.Errorf("split at %d: recorded %q in events %q, want %q", split, joined, outputs, text)
+		}
+	}
+}
+
+func TestWriteHoldsBackPartialCharacter(t *testing.T) {
+	var b bytes.Buffer
+	w, err := asciicast.NewWriter(&b, asciicast.NewHeader(""), func() time.Duration {
+		return 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dot := []byte("●")
+	w.Write(dot[:1])
+	w.Write(dot[1:2])
+	if _, outputs := readCast(t, b.Bytes()); len(outputs) != 0 {
+		t.Fatalf("recorded %q before the character was complete", outputs)
+	}
+	w.Write(dot[2:])
+	if _, outputs := readCast(t, b.Bytes()); (len(outputs) != 1) || (outputs[0] != "●") {
+		t.Errorf("recorded %q, want one event with the whole character", outputs)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jessecrossen/go81/asciicast"
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/record"
+	"github.com/jessecrossen/go81/render"
+)
+
+// the title given to asciicast recordings
+const castTitle = "go81"
+
+// whether a recording should be written as an asciicast
+func isCastPath(path string) bool {
+	return strings.HasSuffix(path, ".cast")
+}
+
+// create an asciicast file and a writer that records output to it
+func createCast(path string, clock asciicast.Clock) (*os.File, *asciicast.Writer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	w, err := asciicast.NewWriter(file, asciicast.NewHeader(castTitle), clock)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, w, nil
+}
+
+// write a replay to an asciicast file as fast as possible, timestamped with game time
+func exportCast(player *record.Player, path string, speed float64) error {
+	clock := func() time.Duration {
+		return time.Duration(float64(time.Duration(player.Tick())*engine.TickDuration) / speed)
+	}
+	file, w, err := createCast(path, clock)
+	if err != nil {
+		return err
+	}
+	lastFrame := render.NewFrame()
+	for {
+		needsRender, ok := player.Step()
+		if !ok {
+			break
+		}
+		if needsRender {
+			thisFrame := render.Game(player.Game(), render.DefaultTheme)
+			if _, err := w.Write([]byte(thisFrame.Replace(lastFrame))); err != nil {
+				file.Close()
+				return err
+			}
+			lastFrame = thisFrame
+		}
+	}
+	return file.Close()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/render"
+	"github.com/jessecrossen/go81/terminal"
+)
+
+// the format of dates naming daily puzzles
+const dateFormat = "2006-01-02"
+
+// play the puzzle for a day and print a summary to share
+func daily(args []string) {
+	flags := flag.NewFlagSet("daily", flag.ExitOnError)
+	date := flags.String("date", time.Now().Format(dateFormat), "the day to play the puzzle for, like 2024-03-09")
+	reducedMotion := flags.Bool("reduced-motion", false, "turn off transient visual effects")
+	flags.Parse(args)
+	day, err := time.Parse(dateFormat, *date)
+	if err != nil {
+		flags.Usage()
+		os.Exit(2)
+	}
+	puzzle, err := engine.DailyPuzzle(day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	game := engine.NewPuzzleGame(puzzle)
+	game.SetReducedMotion(*reducedMotion)
+	terminal.EnableRawMode()
+	playUntilQuit(game, terminal.NewInput())
+	terminal.Restore()
+	if game.Over() {
+		fmt.Print(shareSummary("go81 daily "+game.Puzzle().Name, game))
+	}
+}
+
+// play a puzzle or drill interactively until the player quits
+func playUntilQuit(game *engine.Game, input <-chan rune) {
+	timer := terminal.NewTimer(engine.TickDuration)
+	display, displayDone := terminal.NewDisplayTo(os.Stdout)
+	for {
+		select {
+		case c := <-input:
+			if c == 'q' {
+				close(display)
+				<-displayDone
+				return
+			}
+			game.Input(c)
+		case _ = <-timer:
+			if game.Step() {
+				display <- render.Game(game, render.DefaultTheme)
+			}
+		}
+	}
+}
+
+// summarize how a puzzle went in a form to share
+func shareSummary(title string, game *engine.Game) string {
+	seconds := game.Elapsed() / time.Second
+	mistakes := "mistakes"
+	if game.Mistakes() == 1 {
+		mistakes = "mistake"
+	}
+	return fmt.Sprintf("%s\nFound %d of %d sets in %d:%02d with %d %s\n", title,
+		len(game.FoundSets()), game.Puzzle().Sets(), seconds/60, seconds%60, game.Mistakes(), mistakes)
+}
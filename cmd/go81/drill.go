@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/terminal"
+)
+
+// practice picking the card that completes a set
+func drill(args []string) {
+	flags := flag.NewFlagSet("drill", flag.ExitOnError)
+	reducedMotion := flags.Bool("reduced-motion", false, "turn off transient visual effects")
+	scoringName := flags.String("scoring", "classic", "how to score answers: "+strings.Join(scoringNames(), ", "))
+	flags.Parse(args)
+	scoring, ok := engine.ScoringPolicies[*scoringName]
+	if !ok {
+		flags.Usage()
+		os.Exit(2)
+	}
+	game := engine.NewDrill(time.Now().UnixNano())
+	game.SetScoring(scoring)
+	game.SetReducedMotion(*reducedMotion)
+	terminal.EnableRawMode()
+	playUntilQuit(game, terminal.NewInput())
+	terminal.Restore()
+	fmt.Printf("reached level %d of %d\n", game.DrillLevel(), engine.MaxDrillLevel)
+	for i, stats := range game.DrillStats() {
+		if stats.Asked > 0 {
+			fmt.Printf("%-6s %3.0f%% right, %.1fs per answer\n", engine.AttributeNames[i],
+				stats.Accuracy()*100, stats.MeanTime().Seconds())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// search random tables for puzzles and write them as a pack
+func generate(args []string) {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	cards := flags.Int("cards", engine.PuzzleSize, "the number of cards on each table")
+	sets := flags.Int("sets", engine.PuzzleSets, "the exact number of sets on each table")
+	count := flags.Int("count", 10, "the number of puzzles to generate")
+	seed := flags.Int64("seed", 1, "the seed of the first puzzle, with each puzzle after it using the next seed")
+	difficultyName := flags.String("difficulty", "normal", "which sets to allow: "+strings.Join(difficultyNames(), ", "))
+	name := flags.String("name", "puzzles", "the name of the pack")
+	outPath := flags.String("o", "", "write the pack to this file instead of standard output")
+	flags.Parse(args)
+	difficulty, ok := engine.Difficulties[*difficultyName]
+	if !ok || (*count <= 0) || (*sets < 0) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	pack := engine.Pack{Name: *name}
+	for i := 0; i < *count; i++ {
+		puzzle, err := engine.NewPuzzle(strconv.Itoa(i+1), *seed+int64(i), *cards, *sets, difficulty)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		pack.Puzzles = append(pack.Puzzles, puzzle)
+	}
+	var w io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := pack.Write(w); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
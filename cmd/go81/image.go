@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/raster"
+	"github.com/jessecrossen/go81/record"
+	"github.com/jessecrossen/go81/render"
+)
+
+// write a replay to an image file, either as an animated GIF or as the single
+// frame shown at the given game time, where a negative time means the end;
+// PNG, HTML and SVG files always get a single frame
+func exportImage(player *record.Player, path string, at time.Duration) error {
+	single := !strings.HasSuffix(path, ".gif") || (at >= 0)
+	animation := raster.Animation{}
+	frame := render.Game(player.Game(), render.DefaultTheme)
+	for {
+		now := time.Duration(player.Tick()) * engine.TickDuration
+		if single && (at >= 0) && (now >= at) {
+			break
+		}
+		needsRender, ok := player.Step()
+		if !ok {
+			break
+		}
+		if needsRender {
+			frame = render.Game(player.Game(), render.DefaultTheme)
+			if !single {
+				animation.Add(frame, now)
+			}
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	switch {
+	case strings.HasSuffix(path, ".png"):
+		err = raster.WritePNG(file, &frame)
+	case strings.HasSuffix(path, ".html"):
+		_, err = file.WriteString(frame.HTML())
+	case strings.HasSuffix(path, ".svg"):
+		_, err = file.WriteString(frame.SVG())
+	default:
+		if single {
+			animation.Add(frame, 0)
+		}
+		err = animation.WriteGIF(file)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
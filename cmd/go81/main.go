@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// a subcommand, which parses its own arguments
+type command struct {
+	run     func(args []string)
+	summary string
+}
+
+// subcommands available in addition to playing the game
+var commands = map[string]command{
+	"daily":    {daily, "find every set in the puzzle of the day"},
+	"drill":    {drill, "practice picking the card that completes a set"},
+	"generate": {generate, "search random tables for puzzles and write them as a pack"},
+	"pack":     {playPack, "play the puzzles in a pack in order"},
+	"replay":   {replay, "play back a recorded game"},
+	"simulate": {simulate, "play many games with a bot and report statistics"},
+	"solve":    {solve, "find every set among cards written like 2RSo"},
+	"train":    {train, "practice telling whether three cards form a set"},
+	"verify":   {verify, "check the outcome claimed for a recorded game"},
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			cmd.run(os.Args[2:])
+			return
+		}
+		if os.Args[1] == "help" {
+			usage()
+			return
+		}
+	}
+	play(os.Args[1:])
+}
+
+// print the available subcommands
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: go81 [command] [flags]")
+	fmt.Fprintln(os.Stderr, "\nWith no command, play a game. Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/terminal"
+)
+
+// play the puzzles in a pack in order, skipping those already solved
+func playPack(args []string) {
+	flags := flag.NewFlagSet("pack", flag.ExitOnError)
+	list := flags.Bool("list", false, "list the puzzles in the pack and which are solved instead of playing")
+	reducedMotion := flags.Bool("reduced-motion", false, "turn off transient visual effects")
+	progressPath := flags.String("progress", defaultProgressPath(), "where to keep track of solved puzzles")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: go81 pack [flags] file")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	pack, key, err := readPack(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// stop rather than replace progress that can't be read
+	progress, err := loadProgress(*progressPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	solved := make(map[string]bool)
+	for _, name := range progress[key] {
+		solved[name] = true
+	}
+	if *list {
+		for _, puzzle := range pack.Puzzles {
+			mark := " "
+			if solved[puzzle.Name] {
+				mark = "✓"
+			}
+			fmt.Printf("%s %-10s %2d cards  %2d sets  difficulty %.2f\n",
+				mark, puzzle.Name, len(puzzle.Cards), puzzle.Sets(), puzzle.Difficulty())
+		}
+		return
+	}
+	terminal.EnableRawMode()
+	defer terminal.Restore()
+	input := terminal.NewInput()
+	for i, puzzle := range pack.Puzzles {
+		if solved[puzzle.Name] {
+			continue
+		}
+		game := engine.NewPuzzleGame(puzzle)
+		game.SetReducedMotion(*reducedMotion)
+		playUntilQuit(game, input)
+		if !game.Over() {
+			return
+		}
+		progress[key] = append(progress[key], puzzle.Name)
+		if err := saveProgress(*progressPath, progress); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to save progress:", err)
+		}
+		fmt.Print(shareSummary(fmt.Sprintf("go81 %s %s", pack.Name, puzzle.Name), game))
+		if i == len(pack.Puzzles)-1 {
+			break
+		}
+		fmt.Print("Play the next puzzle? [Y/n] ")
+		c := <-input
+		fmt.Println()
+		if c == 'n' || c == 'N' || c == 'q' {
+			return
+		}
+	}
+	fmt.Println("Every puzzle in the pack is solved.")
+}
+
+// read a puzzle pack, returning a key that identifies it in the progress file
+func readPack(path string) (engine.Pack, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return engine.Pack{}, "", err
+	}
+	defer file.Close()
+	pack, err := engine.ReadPack(file)
+	if err != nil {
+		return pack, "", fmt.Errorf("%s: %v", path, err)
+	}
+	key, err := filepath.Abs(path)
+	if err != nil {
+		key = path
+	}
+	return pack, key, nil
+}
+
+// get the default location of the record of solved puzzles
+func defaultProgressPath() string {
+	return filepath.Join(filepath.Dir(defaultSavePath()), "progress.json")
+}
+
+// load the names of solved puzzles for each pack, or nothing if there's no record yet
+func loadProgress(path string) (map[string][]string, error) {
+	progress := make(map[string][]string)
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return progress, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(&progress); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return progress, nil
+}
+
+// save the names of solved puzzles for each pack
+func saveProgress(path string, progress map[string][]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(file).Encode(progress); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	if progress, err := loadProgress(path); (len(progress) != 0) || (err != nil) {
+		t.Errorf("loaded %v, %v from missing progress, want nothing", progress, err)
+	}
+	saved := map[string][]string{"pack": {"one", "two"}}
+	if err := saveProgress(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	if progress, err := loadProgress(path); !reflect.DeepEqual(progress, saved) || (err != nil) {
+		t.Errorf("loaded %v, %v, want %v", progress, err, saved)
+	}
+	if err := os.WriteFile(path, []byte(`{"pack":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if progress, err := loadProgress(path); (progress != nil) || (err == nil) {
+		t.Errorf("loaded %v, %v from truncated progress, want an error", progress, err)
+	}
+}
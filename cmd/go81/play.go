@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jessecrossen/go81/asciicast"
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/record"
+	"github.com/jessecrossen/go81/render"
+	"github.com/jessecrossen/go81/terminal"
+)
+
+// play a game interactively
+func play(args []string) {
+	flags := flag.NewFlagSet("go81", flag.ExitOnError)
+	reducedMotion := flags.Bool("reduced-motion", false, "turn off transient visual effects")
+	savePath := flags.String("save", defaultSavePath(), "where to save a game in progress on quit")
+	recordPath := flags.String("record", "",
+		"record a new game to this file for replaying, or as an asciicast if it ends in .cast")
+	rulesName := flags.String("rules", "classic", "the variant to play a new game by: "+strings.Join(variantNames(), ", "))
+	difficultyName := flags.String("difficulty", "normal",
+		"which sets to deal cards for in a new game: "+strings.Join(difficultyNames(), ", "))
+	scoringName := flags.String("scoring", "classic",
+		"how to score a new game: "+strings.Join(scoringNames(), ", "))
+	flags.Parse(args)
+	rules, ok := engine.Variants[*rulesName]
+	difficulty, difficultyOK := engine.Difficulties[*difficultyName]
+	scoring, scoringOK := engine.ScoringPolicies[*scoringName]
+	if !ok || !difficultyOK || !scoringOK {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if !difficulty.Supports(rules) {
+		fmt.Fprintf(os.Stderr, "%s rules can only be played at normal difficulty\n", rules.Name())
+		os.Exit(2)
+	}
+	// resume a saved game or make a new one, since only new games can be recorded
+	var game *engine.Game
+	if *recordPath == "" {
+		var err error
+		if game, err = loadGame(*savePath); err != nil {
+			fmt.Fprintln(os.Stderr, "can't resume the saved game, which quitting will replace:", err)
+		}
+	}
+	terminal.EnableRawMode()
+	defer terminal.Restore()
+	input := terminal.NewInput()
+	if game != nil {
+		// the saved game keeps its own settings, so name any that differ from the ones asked for
+		settings := savedSettings(game, rules, difficulty, scoring)
+		if len(settings) > 0 {
+			fmt.Printf("Resume saved game with %s? [Y/n] ", strings.Join(settings, ", "))
+		} else {
+			fmt.Print("Resume saved game? [Y/n] ")
+		}
+		c := <-input
+		fmt.Println()
+		if c == 'n' || c == 'N' {
+			game = nil
+		}
+	}
+	var recorder *record.Recorder
+	if game == nil {
+		seed := time.Now().UnixNano()
+		game = engine.NewGameWithDifficulty(seed, rules, difficulty)
+		game.SetScoring(scoring)
+		if (*recordPath != "") && !isCastPath(*recordPath) {
+			recorder = record.NewRecorder(game)
+		}
+	}
+	game.SetReducedMotion(*reducedMotion)
+	// make channels that update the game, capturing the display if needed
+	timer := terminal.NewTimer(engine.TickDuration)
+	var output io.Writer = os.Stdout
+	var cast *os.File
+	if isCastPath(*recordPath) {
+		var castWriter io.Writer
+		var err error
+		cast, castWriter, err = createCast(*recordPath, asciicast.WallClock())
+		if err != nil {
+			terminal.Restore()
+			fmt.Fprintln(os.Stderr, "failed to record:", err)
+			os.Exit(1)
+		}
+		output = io.MultiWriter(os.Stdout, castWriter)
+	}
+	display, displayDone := terminal.NewDisplayTo(output)
+	// start the interactive loop
+	for {
+		select {
+		case c := <-input:
+			if c == 'q' {
+				// recorded games are never resumed, so they leave any saved game alone
+				if *recordPath == "" {
+					if err := saveGame(*savePath, game); err != nil {
+						fmt.Fprintln(os.Stderr, "failed to save game:", err)
+					}
+				}
+				if recorder != nil {
+					if err := writeLog(*recordPath, recorder.Log()); err != nil {
+						fmt.Fprintln(os.Stderr, "failed to write recording:", err)
+					}
+				}
+				close(display)
+				<-displayDone
+				if cast != nil {
+					if err := cast.Close(); err != nil {
+						fmt.Fprintln(os.Stderr, "failed to write recording:", err)
+					}
+				}
+				return
+			}
+			// only record inputs the game accepts, so recordings always verify
+			if game.CheckInput(c) == nil {
+				game.Input(c)
+				if recorder != nil {
+					recorder.Input(c)
+				}
+			}
+		case _ = <-timer:
+			if recorder != nil {
+				recorder.Tick()
+			}
+			if game.Step() {
+				display <- render.Game(game, render.DefaultTheme)
+			}
+		}
+	}
+}
+
+// describe the settings of a saved game that differ from the given ones
+func savedSettings(game *engine.Game, rules engine.Rules, difficulty engine.Difficulty,
+	scoring engine.ScoringPolicy) []string {
+	settings := make([]string, 0)
+	if game.Rules().Name() != rules.Name() {
+		settings = append(settings, "rules "+game.Rules().Name())
+	}
+	if game.Difficulty() != difficulty {
+		settings = append(settings, "difficulty "+game.Difficulty().String())
+	}
+	if game.Scoring().Name() != scoring.Name() {
+		settings = append(settings, "scoring "+game.Scoring().Name())
+	}
+	return settings
+}
+
+// get the names of the built-in variants in order
+func variantNames() []string {
+	names := make([]string, 0, len(engine.Variants))
+	for name := range engine.Variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// get the names of the difficulty levels in order
+func difficultyNames() []string {
+	names := make([]string, 0, len(engine.Difficulties))
+	for name := range engine.Difficulties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// get the names of the built-in scoring policies in order
+func scoringNames() []string {
+	names := make([]string, 0, len(engine.ScoringPolicies))
+	for name := range engine.ScoringPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/record"
+	"github.com/jessecrossen/go81/render"
+	"github.com/jessecrossen/go81/terminal"
+)
+
+// play back a recorded game
+func replay(args []string) {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := flags.Float64("speed", 1.0, "playback speed relative to the original game")
+	step := flags.Bool("step", false, "advance one frame each time a key is pressed")
+	castPath := flags.String("cast", "", "write the replay to this file as an asciicast instead of showing it")
+	imagePath := flags.String("image", "",
+		"write the replay to this file as an animated GIF, or a single frame if it ends in .png, .html or .svg")
+	at := flags.Duration("at", -1, "with -image, write only the frame shown at this game time")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: go81 replay [flags] file")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 || *speed <= 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	log, err := readLog(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	player := record.NewPlayer(log)
+	if *castPath != "" {
+		if err := exportCast(player, *castPath, *speed); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to export:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *imagePath != "" {
+		if err := exportImage(player, *imagePath, *at); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to export:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	terminal.EnableRawMode()
+	defer terminal.Restore()
+	input := terminal.NewInput()
+	display := terminal.NewDisplay()
+	// wait for a key between frames, or advance on a timer
+	var timer <-chan int
+	keys := input
+	if *step {
+		keys = nil
+		frames := make(chan int)
+		go func() {
+			for frame := 0; ; frame++ {
+				if <-input == 'q' {
+					close(frames)
+					return
+				}
+				frames <- frame
+			}
+		}()
+		timer = frames
+	} else {
+		interval := time.Duration(float64(engine.TickDuration) / *speed)
+		timer = terminal.NewTimer(interval)
+	}
+	for {
+		select {
+		case c := <-keys:
+			if c == 'q' {
+				return
+			}
+		case _, ok := <-timer:
+			if !ok {
+				return
+			}
+			// in step mode, skip ahead to the next tick that changes the frame
+			for {
+				needsRender, more := player.Step()
+				if !more {
+					return
+				}
+				if needsRender {
+					display <- render.Game(player.Game(), render.DefaultTheme)
+					break
+				}
+				if !*step {
+					break
+				}
+			}
+		}
+	}
+}
+
+// read a recorded game from a file
+func readLog(path string) (record.Log, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return record.Log{}, err
+	}
+	defer file.Close()
+	return record.Read(file)
+}
+
+// write a recorded game to a file
+func writeLog(path string, log record.Log) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := log.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// get the default location of the saved game
+func defaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go81", "save.json")
+}
+
+// load a saved game, returning nil if there isn't one that can be resumed
+// and an error if there is one that can't be read
+func loadGame(path string) (*engine.Game, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	game, err := engine.LoadGame(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if game.Over() {
+		return nil, nil
+	}
+	return game, nil
+}
+
+// save a game in progress, or remove the saved game if it's over
+func saveGame(path string, game *engine.Game) error {
+	if game.Over() {
+		err := os.Remove(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := game.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+func TestLoadGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if game, err := loadGame(path); (game != nil) || (err != nil) {
+		t.Errorf("loaded %v, %v from a missing save, want nothing", game, err)
+	}
+	saved := engine.NewGameWithRules(1, engine.UltraRules{})
+	saved.SetScoring(engine.StreakScoring{})
+	if err := saveGame(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	game, err := loadGame(path)
+	if (game == nil) || (err != nil) {
+		t.Fatalf("loaded %v, %v, want the saved game", game, err)
+	}
+	settings := savedSettings(game, engine.ClassicRules{}, engine.DifficultyNormal, engine.ClassicScoring{})
+	if (len(settings) != 2) || (settings[0] != "rules ultra") || (settings[1] != "scoring streak") {
+		t.Errorf("described saved settings as %q", settings)
+	}
+	if err := os.WriteFile(path, []byte(`{"version":99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if game, err := loadGame(path); (game != nil) || (err == nil) {
+		t.Errorf("loaded %v, %v from a save with a bad version, want an error", game, err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/sim"
+)
+
+// play many games with a bot and report statistics
+func simulate(args []string) {
+	botNames := make([]string, 0, len(sim.Bots))
+	for name := range sim.Bots {
+		botNames = append(botNames, name)
+	}
+	sort.Strings(botNames)
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	games := flags.Int("games", 1000, "the number of games to play")
+	seed := flags.Int64("seed", 1, "the seed of the first game, with each game after it using the next seed")
+	rulesName := flags.String("rules", "classic", "the variant to play by: "+strings.Join(variantNames(), ", "))
+	botName := flags.String("bot", "first", "the strategy to play with: "+strings.Join(botNames, ", "))
+	asCSV := flags.Bool("csv", false, "write the report as CSV")
+	flags.Parse(args)
+	rules, rulesOK := engine.Variants[*rulesName]
+	newBot, ok := sim.Bots[*botName]
+	if !ok || !rulesOK || *games <= 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	stats := sim.Stats{}
+	for i := 0; i < *games; i++ {
+		gameSeed := *seed + int64(i)
+		stats.Add(sim.Play(gameSeed, rules, newBot(gameSeed)))
+	}
+	var err error
+	if *asCSV {
+		err = stats.WriteCSV(os.Stdout)
+	} else {
+		err = stats.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+// find every set among cards written in card notation
+func solve(args []string) {
+	flags := flag.NewFlagSet("solve", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: go81 solve [card ...]")
+		fmt.Fprintln(os.Stderr, "\nCards are a count, color, fill and shape, like 2RSo for two red striped ovals.")
+		fmt.Fprintln(os.Stderr, "Colors are R, G and P, fills are O, S and F, and shapes are d, s and o.")
+		fmt.Fprintln(os.Stderr, "With no cards as arguments, read them from standard input.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	text := strings.Join(flags.Args(), " ")
+	if flags.NArg() == 0 {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		text = string(input)
+	}
+	cards, err := parseCards(text)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ids := make([]int, len(cards))
+	for i := range cards {
+		ids[i] = cards[i].ID()
+	}
+	sets := solver.Sets(ids)
+	for _, set := range sets {
+		fmt.Printf("%s %s %s\n",
+			cards[set[0]].Notation(), cards[set[1]].Notation(), cards[set[2]].Notation())
+	}
+	fmt.Printf("sets: %d\ncap: %v\n", len(sets), len(sets) == 0)
+}
+
+// parse cards separated by spaces or commas, rejecting duplicates
+func parseCards(text string) ([]engine.Card, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || (r == ',')
+	})
+	cards := make([]engine.Card, 0, len(fields))
+	seen := make(map[int]bool)
+	for _, field := range fields {
+		card, err := engine.ParseCard(field)
+		if err != nil {
+			return nil, err
+		}
+		if seen[card.ID()] {
+			return nil, fmt.Errorf("duplicate card %s", card.Notation())
+		}
+		seen[card.ID()] = true
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
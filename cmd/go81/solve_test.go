@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestParseCards(t *testing.T) {
+	for _, c := range []struct {
+		text  string
+		cards int
+		ok    bool
+	}{
+		{"", 0, true},
+		{"1ROd 2RSo 3RFs", 3, true},
+		{"1ROd,2RSo,\n3RFs", 3, true},
+		{"1ROd 2RSo 1ROd", 0, false},
+		{"1ROd 1rEt", 0, false},
+		{"1ROd 2RSx", 0, false},
+	} {
+		cards, err := parseCards(c.text)
+		if (err == nil) != c.ok {
+			t.Errorf("%q: got error %v, want ok %v", c.text, err, c.ok)
+		} else if len(cards) != c.cards {
+			t.Errorf("%q: parsed %d cards, want %d", c.text, len(cards), c.cards)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/terminal"
+)
+
+// practice telling whether three cards form a set
+func train(args []string) {
+	flags := flag.NewFlagSet("train", flag.ExitOnError)
+	reducedMotion := flags.Bool("reduced-motion", false, "turn off transient visual effects")
+	statsPath := flags.String("stats", defaultTrainerStatsPath(),
+		"where to keep track of mistakes between sessions")
+	scoringName := flags.String("scoring", "classic", "how to score answers: "+strings.Join(scoringNames(), ", "))
+	flags.Parse(args)
+	scoring, ok := engine.ScoringPolicies[*scoringName]
+	if !ok {
+		flags.Usage()
+		os.Exit(2)
+	}
+	// stop rather than replace stats that can't be read
+	stats, err := loadTrainerStats(*statsPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	game := engine.NewTrainer(time.Now().UnixNano(), stats)
+	game.SetScoring(scoring)
+	game.SetReducedMotion(*reducedMotion)
+	terminal.EnableRawMode()
+	playUntilQuit(game, terminal.NewInput())
+	terminal.Restore()
+	if err := saveTrainerStats(*statsPath, game.TrainerStats()); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to save stats:", err)
+	}
+	for i, stats := range game.TrainerStats() {
+		if stats.Asked > 0 {
+			fmt.Printf("%-6s missed %d of %d\n", engine.AttributeNames[i], stats.Asked-stats.Correct, stats.Asked)
+		}
+	}
+}
+
+// get the default location of the trainer's record of mistakes
+func defaultTrainerStatsPath() string {
+	return filepath.Join(filepath.Dir(defaultSavePath()), "trainer.json")
+}
+
+// load the trainer's stats by attribute name, or nothing if there's no record yet
+func loadTrainerStats(path string) ([]engine.AttributeStats, error) {
+	stats := make([]engine.AttributeStats, len(engine.AttributeNames))
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return stats, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	saved := make(map[string]engine.AttributeStats)
+	if err := json.NewDecoder(file).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for i, name := range engine.AttributeNames {
+		stats[i] = saved[name]
+	}
+	return stats, nil
+}
+
+// save the trainer's stats by attribute name
+func saveTrainerStats(path string, stats []engine.AttributeStats) error {
+	saved := make(map[string]engine.AttributeStats)
+	for i, name := range engine.AttributeNames {
+		saved[name] = stats[i]
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(file).Encode(saved); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
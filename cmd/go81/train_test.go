@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+func TestTrainerStatsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trainer.json")
+	empty := make([]engine.AttributeStats, len(engine.AttributeNames))
+	if stats, err := loadTrainerStats(path); !reflect.DeepEqual(stats, empty) || (err != nil) {
+		t.Errorf("loaded %v, %v from missing stats, want empty stats", stats, err)
+	}
+	saved := []engine.AttributeStats{
+		{Asked: 4, Correct: 3, Time: 2 * time.Second},
+		{},
+		{Asked: 1, Correct: 0, Time: time.Second},
+		{Asked: 9, Correct: 9, Time: 5 * time.Second},
+	}
+	if err := saveTrainerStats(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	if stats, err := loadTrainerStats(path); !reflect.DeepEqual(stats, saved) || (err != nil) {
+		t.Errorf("loaded %v, %v, want %v", stats, err, saved)
+	}
+	if err := os.WriteFile(path, []byte(`{"color":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if stats, err := loadTrainerStats(path); (stats != nil) || (err == nil) {
+		t.Errorf("loaded %v, %v from truncated stats, want an error", stats, err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jessecrossen/go81/record"
+)
+
+// check that a recorded game produces a claimed outcome
+func verify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	score := flags.Int("score", 0, "the claimed final score")
+	sets := flags.Int("sets", 0, "the claimed number of sets found")
+	duration := flags.Duration("duration", 0, "the claimed game time, like 3m25.5s")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: go81 verify [flags] file")
+		fmt.Fprintln(os.Stderr, "\nWith no claims, print the outcome of the recorded game.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	log, err := readLog(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	claimed := false
+	flags.Visit(func(*flag.Flag) { claimed = true })
+	if !claimed {
+		result, err := record.Simulate(log)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "rejected:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("score: %d\nsets: %d\nduration: %v\n", result.Score, result.Sets, result.Duration)
+		return
+	}
+	claim := record.Result{Score: *score, Sets: *sets, Duration: *duration}
+	if err := record.Verify(log, claim); err != nil {
+		fmt.Fprintln(os.Stderr, "rejected:", err)
+		os.Exit(1)
+	}
+	fmt.Println("verified")
+}
@@ -1,4 +1,6 @@
-package main
+package engine
+
+import "sort"
 
 // AnimationAction represents a function called for each step of an animation that returns false when the animation is over.
 type AnimationAction = func(int) bool
@@ -10,6 +12,14 @@ type Animation struct {
 	step    int             // the number of times action has been invoked
 }
 
+// queue an animation to start once this one and any it starts have finished
+func (a *Animation) then(next *Animation) {
+	for a.andThen != nil {
+		a = a.andThen
+	}
+	a.andThen = next
+}
+
 // Animator stores and applies a set of animations.
 type Animator struct {
 	animations map[int]*Animation
@@ -29,10 +39,16 @@ func (a *Animator) Animate(animation Animation) {
 	a.nextIndex++
 }
 
-// Step applies all running animations and returns whether any were running.
+// Step applies all running animations in the order they were added and returns whether any were running.
 func (a *Animator) Step() bool {
+	indices := make([]int, 0, len(a.animations))
+	for i := range a.animations {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
 	anyRunning := false
-	for i, animation := range a.animations {
+	for _, i := range indices {
+		animation := a.animations[i]
 		anyRunning = true
 		stillRunning := animation.action(animation.step)
 		animation.step++
@@ -0,0 +1,96 @@
+package engine
+
+// A Card describes one card in a deck of cards.
+type Card struct {
+	id       int       // which card this is, coded from its attributes
+	spec     *DeckSpec // the deck the card belongs to, or nil for the classic deck
+	dots     int       // the dots on a ProSET card as a bitmask, or 0 for other cards
+	col      int       // the column to render the left edge of the card at
+	row      int       // the row to render the top edge of the card at
+	turn     int       // vary this to animate the card flipping over (0 to 8)
+	shrink   int       // vary this to animate the card shrinking (0 to 5)
+	selected bool      // whether the card has been selected by the user
+	layer    int       // z-index of the card, where layers 0 or lower are never drawn
+}
+
+// Possible values for the layer property of a card.
+const (
+	LayerCollected  = -2
+	LayerToDeal     = -1
+	LayerNotDealt   = 0
+	LayerDealt      = 1
+	LayerCollecting = 2
+	LayerDealing    = 3
+)
+
+// MaxShrink is the maximum value of the shrink property of a card.
+const MaxShrink = 5
+
+// FrontTurn is the value of a card's turn property that shows the front.
+const FrontTurn = 0
+
+// BackTurn is the value of a card's turn property that shows the back.
+const BackTurn = 4
+
+// NewCard returns a card from the classic deck with the given id that isn't on the table.
+func NewCard(id int) Card {
+	return Card{id: id}
+}
+
+// ID returns which card this is, coded from its attributes.
+func (c *Card) ID() int {
+	return c.id
+}
+
+// Position returns the column and row of the top left corner of the card.
+func (c *Card) Position() (col, row int) {
+	return c.col, c.row
+}
+
+// Turn returns how far the card has been flipped over, where FrontTurn shows the front.
+func (c *Card) Turn() int {
+	return c.turn
+}
+
+// Shrink returns how far the card has shrunk, from 0 to MaxShrink.
+func (c *Card) Shrink() int {
+	return c.shrink
+}
+
+// Selected returns whether the card has been selected by the user.
+func (c *Card) Selected() bool {
+	return c.selected
+}
+
+// Layer returns the z-index of the card, where layers 0 or lower are never drawn.
+func (c *Card) Layer() int {
+	return c.layer
+}
+
+// Attributes returns how the card is drawn, where count ranges from 1 to 3
+// and all others range from 0 to 2.
+func (c *Card) Attributes() (count, shape, fill, clr int) {
+	return c.Spec().features(c.id)
+}
+
+// Dots returns the dots on a ProSET card as a bitmask with ProSetDots bits,
+// or 0 if the card isn't from a ProSET deck.
+func (c *Card) Dots() int {
+	return c.dots
+}
+
+// Spec returns the kind of deck the card belongs to.
+func (c *Card) Spec() *DeckSpec {
+	if c.spec == nil {
+		return &ClassicDeck
+	}
+	return c.spec
+}
+
+// A Deck stores a complete deck of cards, where each card's id is its index.
+type Deck = []Card
+
+// NewDeck creates a complete classic deck of 81 cards.
+func NewDeck() Deck {
+	return ClassicDeck.NewDeck()
+}
@@ -0,0 +1,154 @@
+package engine
+
+import "fmt"
+
+// A Feature is a way the face of a card can vary when it's drawn.
+type Feature int
+
+// Features a card's attributes can be drawn as.
+const (
+	FeatureCount Feature = iota // how many symbols are on the card
+	FeatureShape                // the shape of the symbols
+	FeatureFill                 // how the symbols are filled in
+	FeatureColor                // the color of the symbols
+)
+
+// A DeckSpec describes a deck with one card for each combination of values
+// of its attributes. A card's id holds the value of each attribute as a digit
+// in base Values, with the first attribute in the least significant digit.
+type DeckSpec struct {
+	Attributes int       // the number of attributes cards vary by
+	Values     int       // the number of values each attribute takes, which is also the number of cards in a set
+	Features   []Feature // how each attribute is drawn, in order, where features no attribute is drawn as stay fixed
+}
+
+// ClassicDeck is the standard deck of 81 cards with four attributes.
+var ClassicDeck = DeckSpec{
+	Attributes: 4,
+	Values:     3,
+	Features:   []Feature{FeatureCount, FeatureShape, FeatureFill, FeatureColor},
+}
+
+// JuniorDeck is a deck of 27 cards with three attributes, where every symbol is filled.
+var JuniorDeck = DeckSpec{
+	Attributes: 3,
+	Values:     3,
+	Features:   []Feature{FeatureCount, FeatureShape, FeatureColor},
+}
+
+// how features are drawn when no attribute varies them, indexed by feature
+var fixedFeatures = [4]int{0, 0, 2, 0}
+
+// the number of values each feature can be drawn with
+const featureValues = 3
+
+// Size returns the number of cards in the deck.
+func (s *DeckSpec) Size() int {
+	size := 1
+	for i := 0; i < s.Attributes; i++ {
+		size *= s.Values
+	}
+	return size
+}
+
+// Attribute returns the value of an attribute of the card with the given id.
+func (s *DeckSpec) Attribute(id int, attribute int) int {
+	for i := 0; i < attribute; i++ {
+		id /= s.Values
+	}
+	return id % s.Values
+}
+
+// Validate returns an error if cards following the spec can't be drawn, since
+// each attribute needs a feature of its own with a face for every value.
+func (s *DeckSpec) Validate() error {
+	if (s.Values < 2) || (s.Values > featureValues) {
+		return fmt.Errorf("deck has %d values per attribute, expecting 2 to %d", s.Values, featureValues)
+	}
+	if (s.Attributes < 1) || (len(s.Features) != s.Attributes) {
+		return fmt.Errorf("deck has %d attributes drawn as %d features, expecting one feature for each",
+			s.Attributes, len(s.Features))
+	}
+	drawn := make(map[Feature]bool, len(s.Features))
+	for _, feature := range s.Features {
+		if (feature < FeatureCount) || (feature > FeatureColor) {
+			return fmt.Errorf("deck draws an attribute as unknown feature %d", feature)
+		}
+		if drawn[feature] {
+			return fmt.Errorf("deck draws more than one attribute as feature %d", feature)
+		}
+		drawn[feature] = true
+	}
+	return nil
+}
+
+// NewDeck creates a complete deck of cards following the spec, panicking if
+// the spec is invalid.
+func (s *DeckSpec) NewDeck() Deck {
+	if err := s.Validate(); err != nil {
+		panic(err)
+	}
+	spec := *s
+	d := make(Deck, s.Size())
+	for id := range d {
+		d[id].id = id
+		d[id].spec = &spec
+	}
+	return d
+}
+
+// IsSet returns whether the given cards form a set, meaning there is one card
+// for each value of an attribute and each attribute is the same on every card
+// or different on every card.
+func (s *DeckSpec) IsSet(cards []*Card) bool {
+	if len(cards) != s.Values {
+		return false
+	}
+	for attribute := 0; attribute < s.Attributes; attribute++ {
+		seen := make(map[int]bool, s.Values)
+		for _, card := range cards {
+			seen[s.Attribute(card.id, attribute)] = true
+		}
+		if (len(seen) != 1) && (len(seen) != s.Values) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSet returns whether any of the given cards form a set, ignoring nil entries.
+func (s *DeckSpec) HasSet(cards []*Card) bool {
+	present := make([]*Card, 0, len(cards))
+	for _, card := range cards {
+		if card != nil {
+			present = append(present, card)
+		}
+	}
+	return s.hasSetFrom(present, make([]*Card, 0, s.Values))
+}
+
+// IMPLEMENTATION *************************************************************
+
+// extend a partial selection with later cards until it forms a set
+func (s *DeckSpec) hasSetFrom(cards []*Card, selection []*Card) bool {
+	if len(selection) == s.Values {
+		return s.IsSet(selection)
+	}
+	for i, card := range cards {
+		if s.hasSetFrom(cards[i+1:], append(selection, card)) {
+			return true
+		}
+	}
+	return false
+}
+
+// get the features to draw the card with the given id
+func (s *DeckSpec) features(id int) (count, shape, fill, clr int) {
+	values := fixedFeatures
+	for attribute, feature := range s.Features {
+		if attribute < s.Attributes {
+			values[feature] = s.Attribute(id, attribute)
+		}
+	}
+	return values[FeatureCount] + 1, values[FeatureShape], values[FeatureFill], values[FeatureColor]
+}
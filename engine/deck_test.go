@@ -0,0 +1,44 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+func TestDeckSpecValidate(t *testing.T) {
+	for _, spec := range []engine.DeckSpec{engine.ClassicDeck, engine.JuniorDeck,
+		{Attributes: 2, Values: 2, Features: []engine.Feature{engine.FeatureColor, engine.FeatureShape}}} {
+		if err := spec.Validate(); err != nil {
+			t.Errorf("rejected %+v: %v", spec, err)
+		}
+	}
+	count := []engine.Feature{engine.FeatureCount}
+	cases := []struct {
+		name string
+		spec engine.DeckSpec
+	}{
+		{"too many values", engine.DeckSpec{Attributes: 1, Values: 4, Features: count}},
+		{"one value", engine.DeckSpec{Attributes: 1, Values: 1, Features: count}},
+		{"no attributes", engine.DeckSpec{Values: 3}},
+		{"attribute without a feature", engine.DeckSpec{Attributes: 2, Values: 3, Features: count}},
+		{"feature without an attribute", engine.DeckSpec{Attributes: 1, Values: 3,
+			Features: []engine.Feature{engine.FeatureCount, engine.FeatureColor}}},
+		{"feature drawn twice", engine.DeckSpec{Attributes: 2, Values: 3,
+			Features: []engine.Feature{engine.FeatureShape, engine.FeatureShape}}},
+		{"unknown feature", engine.DeckSpec{Attributes: 1, Values: 3, Features: []engine.Feature{4}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.spec.Validate(); err == nil {
+				t.Errorf("accepted %+v", c.spec)
+			}
+			defer func() {
+				if recover() == nil {
+					t.Errorf("made a deck from %+v", c.spec)
+				}
+			}()
+			c.spec.NewDeck()
+		})
+	}
+}
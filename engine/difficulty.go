@@ -0,0 +1,135 @@
+package engine
+
+import "github.com/jessecrossen/go81/solver"
+
+// Difficulty controls which sets cards are dealt to form, judged by how many
+// attributes differ between the cards of each set. Dealing is best-effort:
+// tables only have sets suiting the level while enough cards are left to
+// choose from, and unsuited sets turn up as the deck runs out.
+type Difficulty int
+
+// Difficulty levels.
+const (
+	DifficultyNormal Difficulty = iota // cards are dealt in a random order
+	DifficultyEasy                     // sets differ in one or two attributes
+	DifficultyHard                     // sets differ in all four attributes
+)
+
+// Difficulties holds the difficulty levels by name.
+var Difficulties = map[string]Difficulty{
+	"normal": DifficultyNormal,
+	"easy":   DifficultyEasy,
+	"hard":   DifficultyHard,
+}
+
+// String returns the name of the difficulty level.
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyNormal:
+		return "normal"
+	case DifficultyEasy:
+		return "easy"
+	case DifficultyHard:
+		return "hard"
+	}
+	return "unknown"
+}
+
+// Supports returns whether cards can be dealt for the difficulty level in a
+// game by the given rules, since only sets of classic cards are judged.
+func (d Difficulty) Supports(rules Rules) bool {
+	return (d == DifficultyNormal) || (len(rules.NewDeck()) == solver.DeckSize)
+}
+
+// Allows returns whether sets with the given number of differing attributes
+// belong at the difficulty level.
+func (d Difficulty) Allows(differing int) bool {
+	switch d {
+	case DifficultyEasy:
+		return differing <= 2
+	case DifficultyHard:
+		return differing == 4
+	}
+	return true
+}
+
+// DifferingAttributes returns the number of attributes that aren't the same on every one of the cards.
+func DifferingAttributes(cards []*Card) int {
+	if len(cards) == 0 {
+		return 0
+	}
+	spec := cards[0].Spec()
+	count := 0
+	for attribute := 0; attribute < spec.Attributes; attribute++ {
+		value := spec.Attribute(cards[0].id, attribute)
+		for _, card := range cards[1:] {
+			if spec.Attribute(card.id, attribute) != value {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// IMPLEMENTATION *************************************************************
+
+// pick a card to deal to the table, choosing at random among the cards left
+// that form the fewest sets with the table that don't suit the difficulty
+// level, and that complete a set suiting it if the table has none
+func (g *Game) pickTableCard() *Card {
+	// only the classic deck has sets that differ in four attributes
+	if (g.difficulty == DifficultyNormal) || (len(g.deck) != solver.DeckSize) {
+		return g.pickCard()
+	}
+	needsSet := !solver.HasSet(TableIDs(g.table[:]))
+	best := make([]*Card, 0)
+	bestRank := 0
+	for i := range g.deck {
+		card := &g.deck[i]
+		if card.layer != LayerNotDealt {
+			continue
+		}
+		suited, unsuited := g.countTableSets(card)
+		rank := 2 * unsuited
+		if needsSet && (suited == 0) {
+			rank++
+		}
+		if (len(best) == 0) || (rank < bestRank) {
+			best, bestRank = best[:0], rank
+		}
+		if rank == bestRank {
+			best = append(best, card)
+		}
+	}
+	if len(best) == 0 {
+		return nil
+	}
+	return best[g.draw()%int64(len(best))]
+}
+
+// count the sets a card would form with cards on the table that do and don't suit the difficulty level
+func (g *Game) countTableSets(card *Card) (suited int, unsuited int) {
+	onTable := make(map[int]bool)
+	for _, other := range g.table {
+		if other != nil {
+			onTable[other.id] = true
+		}
+	}
+	for _, other := range g.table {
+		if other == nil {
+			continue
+		}
+		// each set is seen from both of the other cards in it
+		third := solver.Third(card.id, other.id)
+		if (third <= other.id) || !onTable[third] {
+			continue
+		}
+		if g.difficulty.Allows(DifferingAttributes([]*Card{card, other})) {
+			suited++
+		} else {
+			unsuited++
+		}
+	}
+	return suited, unsuited
+}
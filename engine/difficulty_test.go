@@ -0,0 +1,56 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+func TestFirstTableSuitsDifficulty(t *testing.T) {
+	for _, difficulty := range []engine.Difficulty{engine.DifficultyEasy, engine.DifficultyHard} {
+		for seed := int64(1); seed <= 100; seed++ {
+			g := engine.NewGameWithDifficulty(seed, engine.ClassicRules{}, difficulty)
+			for i := 0; i < 200; i++ {
+				g.Step()
+			}
+			ids := make([]int, 0)
+			for _, card := range g.Table() {
+				if card != nil {
+					ids = append(ids, card.ID())
+				}
+			}
+			sets := solver.Sets(ids)
+			if (len(ids) != 12) || (len(sets) == 0) {
+				t.Errorf("%s seed %d: dealt %d cards with %d sets, want 12 with a set",
+					difficulty, seed, len(ids), len(sets))
+			}
+			for _, set := range sets {
+				a, b := engine.NewCard(ids[set[0]]), engine.NewCard(ids[set[1]])
+				if differing := engine.DifferingAttributes([]*engine.Card{&a, &b}); !difficulty.Allows(differing) {
+					t.Errorf("%s seed %d: dealt a set differing in %d attributes", difficulty, seed, differing)
+				}
+			}
+		}
+	}
+}
+
+func TestDifficultyNames(t *testing.T) {
+	for name, difficulty := range engine.Difficulties {
+		if difficulty.String() != name {
+			t.Errorf("%s difficulty is named %q", name, difficulty.String())
+		}
+	}
+}
+
+func TestDifficultySupportsRules(t *testing.T) {
+	for name, rules := range engine.Variants {
+		for _, difficulty := range engine.Difficulties {
+			supported := (difficulty == engine.DifficultyNormal) || (name == "classic") || (name == "ultra")
+			if difficulty.Supports(rules) != supported {
+				t.Errorf("%s difficulty supports %s rules: %v, want %v",
+					difficulty, name, difficulty.Supports(rules), supported)
+			}
+		}
+	}
+}
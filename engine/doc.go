@@ -0,0 +1,9 @@
+// Package engine implements the rules and state of a game similar to SET,
+// with no dependency on how the game is drawn or played.
+//
+// A Game holds a Deck of cards, the cards dealt to the table, and the
+// animations that move cards between the draw pile, the table and the pile
+// of collected sets. Advance it with Game.Step and feed it player input with
+// Game.Input. What the deck holds, what counts as a set and how the table is
+// filled are decided by a Rules value, which is ClassicRules by default.
+package engine
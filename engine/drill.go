@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jessecrossen/go81/solver"
+)
+
+// AttributeNames are the names of the attributes of classic cards, in the
+// order of the digits of their ids.
+var AttributeNames = [4]string{"count", "shape", "fill", "color"}
+
+// DrillChoices is the number of candidates offered for each drill question.
+const DrillChoices = 6
+
+// MaxDrillLevel is the hardest drill level, where every attribute differs
+// between the two cards shown.
+const MaxDrillLevel = 4
+
+// the number of right answers in a row it takes to reach the next drill level
+const drillLevelUp = 3
+
+// AttributeStats tracks answers to questions about an attribute, like drill
+// questions where it differed between the two cards shown.
+type AttributeStats struct {
+	Asked   int           `json:"asked"`   // the number of questions answered
+	Correct int           `json:"correct"` // the number of answers that were right about the attribute
+	Time    time.Duration `json:"time"`    // the total time taken to answer
+}
+
+// Accuracy returns the fraction of answers with the right value of the attribute.
+func (s AttributeStats) Accuracy() float64 {
+	if s.Asked == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Asked)
+}
+
+// MeanTime returns the mean time taken to answer.
+func (s AttributeStats) MeanTime() time.Duration {
+	if s.Asked == 0 {
+		return 0
+	}
+	return s.Time / time.Duration(s.Asked)
+}
+
+// a "complete the set" drill
+type drill struct {
+	g      *Game                               // the game the drill is played in
+	level  int                                 // the number of attributes that differ between the cards shown
+	run    int                                 // right answers in a row at the current level
+	prompt [2]*Card                            // the two cards to complete a set for
+	answer *Card                               // the card that completes the set
+	asked  int                                 // the tick the current question could first be answered at, or -1 while it's dealt
+	stats  [len(AttributeNames)]AttributeStats // answers grouped by the attributes that differed
+}
+
+// NewDrill returns a game where the player is shown two cards and picks
+// the card that completes their set from several candidates, with the cards
+// shown differing in more attributes as the player gets them right.
+func NewDrill(seed int64) *Game {
+	g := newGame(seed, ClassicRules{})
+	d := &drill{g: g, level: 1}
+	g.mode = d
+	d.ask()
+	return g
+}
+
+// DrillLevel returns the number of attributes that differ between the cards
+// shown in a drill, or 0 if the game isn't a drill.
+func (g *Game) DrillLevel() int {
+	d, ok := g.mode.(*drill)
+	if !ok {
+		return 0
+	}
+	return d.level
+}
+
+// DrillStats returns drill answers for each attribute in AttributeNames,
+// or nil if the game isn't a drill.
+func (g *Game) DrillStats() []AttributeStats {
+	d, ok := g.mode.(*drill)
+	if !ok {
+		return nil
+	}
+	return d.stats[:]
+}
+
+// DrillPromptCoords returns the coordinates of one of the two cards shown in a drill question.
+func DrillPromptCoords(i int) (col int, row int) {
+	col, row = DrawPileCoords()
+	col += i * (CardWidth + 2)
+	return
+}
+
+// IMPLEMENTATION *************************************************************
+
+// candidates are selected like cards on a table, once they've all been dealt
+func (d *drill) checkInput(c rune) error {
+	if err := d.g.checkCardInput(c); err != nil {
+		return err
+	}
+	if d.asked < 0 {
+		return fmt.Errorf("the question is still being dealt")
+	}
+	return nil
+}
+
+// selecting a candidate answers the question
+func (d *drill) input(c rune) {
+	if card := d.g.toggleCard(c); card.selected {
+		d.checkAnswer(card)
+	}
+}
+
+// the two cards to complete a set for are shown above the candidates
+func (d *drill) shown() []*Card {
+	return d.prompt[:]
+}
+
+// show two cards and deal candidates for the card that completes their set
+func (d *drill) ask() {
+	g := d.g
+	// cards collected from earlier questions can be used again
+	for i := range g.deck {
+		if g.deck[i].layer == LayerCollected {
+			g.deck[i].layer = LayerNotDealt
+		}
+	}
+	d.asked = -1
+	a, b := d.pickPair()
+	d.prompt = [2]*Card{a, b}
+	d.answer = &g.deck[solver.Third(a.id, b.id)]
+	for i, card := range d.prompt {
+		card.col, card.row = DrillPromptCoords(i)
+		card.shrink = 0
+		card.turn = BackTurn
+		card.layer = LayerDealt
+		g.animator.Animate(*revealAnimation(card))
+	}
+	// offer the answer among cards that differ from it in one attribute that varies
+	candidates := []*Card{d.answer}
+	for _, id := range d.distractors(a, b) {
+		candidates = append(candidates, &g.deck[id])
+	}
+	for i := len(candidates) - 1; i > 0; i-- {
+		j := int(g.draw() % int64(i+1))
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+	next := 0
+	g.dealCards(len(candidates), func() *Card {
+		next++
+		return candidates[next-1]
+	}).then(&Animation{
+		action: func(step int) bool {
+			d.asked = g.elapsed
+			return false
+		},
+	})
+}
+
+// pick two cards differing in the drill's number of attributes whose set can be completed
+func (d *drill) pickPair() (*Card, *Card) {
+	g := d.g
+	for {
+		a := g.pickCard()
+		b := g.pickCard()
+		if (a == b) || (DifferingAttributes([]*Card{a, b}) != d.level) {
+			continue
+		}
+		if g.deck[solver.Third(a.id, b.id)].layer == LayerNotDealt {
+			return a, b
+		}
+	}
+}
+
+// get the ids of cards that haven't been dealt and differ from the third card
+// of a pair in one of the attributes the pair varies in
+func (d *drill) distractors(a *Card, b *Card) []int {
+	g := d.g
+	spec := a.Spec()
+	third := solver.Third(a.id, b.id)
+	// cards from the last question may still be on their way to the collected pile
+	available := func(id int) bool {
+		return (id != third) && (id >= 0) && (id < len(g.deck)) && (g.deck[id].layer == LayerNotDealt)
+	}
+	options := make([]int, 0)
+	place := 1
+	for attribute := 0; attribute < spec.Attributes; attribute++ {
+		value := spec.Attribute(third, attribute)
+		if spec.Attribute(a.id, attribute) != spec.Attribute(b.id, attribute) {
+			for other := 0; other < spec.Values; other++ {
+				if id := third + (other-value)*place; available(id) {
+					options = append(options, id)
+				}
+			}
+		}
+		place *= spec.Values
+	}
+	// fill out the choices with random cards if the pair varies in too few attributes
+	for len(options) < DrillChoices-1 {
+		card := g.pickCard()
+		if available(card.id) && !containsInt(options, card.id) {
+			options = append(options, card.id)
+		}
+	}
+	for i := len(options) - 1; i > 0; i-- {
+		j := int(g.draw() % int64(i+1))
+		options[i], options[j] = options[j], options[i]
+	}
+	return options[:DrillChoices-1]
+}
+
+// score an answer to a drill question and move on to the next one
+func (d *drill) checkAnswer(chosen *Card) {
+	g := d.g
+	chosen.selected = false
+	right := chosen == d.answer
+	a, b := d.prompt[0], d.prompt[1]
+	spec := a.Spec()
+	for attribute := range d.stats {
+		if spec.Attribute(a.id, attribute) == spec.Attribute(b.id, attribute) {
+			continue
+		}
+		stats := &d.stats[attribute]
+		stats.Asked++
+		if spec.Attribute(chosen.id, attribute) == spec.Attribute(d.answer.id, attribute) {
+			stats.Correct++
+		}
+		stats.Time += time.Duration(g.elapsed-d.asked) * TickDuration
+	}
+	centerCol, centerRow := cardsCenter([]*Card{chosen})
+	change := g.scoreSelection([]*Card{a, b, d.answer}, right)
+	g.effects.Add(&g.animator, floatEffect(change, centerCol, centerRow))
+	if right {
+		g.effects.Add(&g.animator, sparkleEffect(chosen.col, chosen.row))
+		g.streak++
+		d.run++
+		if (d.run >= drillLevelUp) && (d.level < MaxDrillLevel) {
+			d.level++
+			d.run = 0
+		}
+		g.publish(SetFound{Cards: cardIDs([]*Card{a, b, d.answer})})
+	} else {
+		g.mistakes++
+		g.streak = 0
+		d.run = 0
+		g.publish(InvalidSet{Cards: cardIDs([]*Card{a, b, chosen})})
+	}
+	// clear away the question and ask the next one
+	col, row := CollectedPileCoords()
+	for _, card := range g.cardsInPlay() {
+		if card != nil {
+			g.removeCardFromTable(card)
+			g.animator.Animate(*collectAnimation(card, col, row))
+		}
+	}
+	d.ask()
+}
@@ -0,0 +1,97 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// step a drill or trainer until its question can be answered with the given input
+func waitForQuestion(t *testing.T, g *engine.Game, input rune) {
+	t.Helper()
+	for i := 0; g.CheckInput(input) != nil; i++ {
+		if i > 1000 {
+			t.Fatalf("question can't be answered: %v", g.CheckInput(input))
+		}
+		g.Step()
+	}
+}
+
+// get the two cards a drill question asks to complete a set for
+func drillPrompt(g *engine.Game) []*engine.Card {
+	onTable := make(map[*engine.Card]bool)
+	for _, card := range g.Table() {
+		onTable[card] = true
+	}
+	var prompt []*engine.Card
+	cards := g.Cards()
+	for i := range cards {
+		if (cards[i].Layer() == engine.LayerDealt) && !onTable[&cards[i]] {
+			prompt = append(prompt, &cards[i])
+		}
+	}
+	return prompt
+}
+
+func TestDrillQuestions(t *testing.T) {
+	g := engine.NewDrill(1)
+	level, run, asked := 1, 0, 0
+	for question := 0; question < 40; question++ {
+		waitForQuestion(t, g, 'a')
+		prompt := drillPrompt(g)
+		if len(prompt) != 2 {
+			t.Fatalf("question %d shows %d cards to complete a set for", question, len(prompt))
+		}
+		if differing := engine.DifferingAttributes(prompt); (differing != level) || (g.DrillLevel() != level) {
+			t.Fatalf("question %d at level %d shows cards differing in %d attributes, want %d",
+				question, g.DrillLevel(), differing, level)
+		}
+		answer, wrong, candidates := -1, -1, 0
+		seen := make(map[*engine.Card]bool)
+		for slot, card := range g.Table() {
+			if card == nil {
+				continue
+			}
+			candidates++
+			if seen[card] || (card == prompt[0]) || (card == prompt[1]) || (card.Layer() != engine.LayerDealt) {
+				t.Fatalf("question %d offers card %d twice, from the prompt or while it's still moving",
+					question, card.ID())
+			}
+			seen[card] = true
+			if !engine.AreSet(prompt[0], prompt[1], card) {
+				wrong = slot
+			} else if answer >= 0 {
+				t.Fatalf("question %d offers more than one card that completes the set", question)
+			} else {
+				answer = slot
+			}
+		}
+		if (candidates != engine.DrillChoices) || (answer < 0) || (wrong < 0) {
+			t.Fatalf("question %d offers %d candidates with the answer at %d, want %d with one answer",
+				question, candidates, answer, engine.DrillChoices)
+		}
+		// get every fifth question wrong
+		asked += level
+		if question%5 == 4 {
+			g.Input(rune('a' + wrong))
+			run = 0
+		} else {
+			g.Input(rune('a' + answer))
+			run++
+			if (run >= 3) && (level < engine.MaxDrillLevel) {
+				level++
+				run = 0
+			}
+		}
+	}
+	if level != engine.MaxDrillLevel {
+		t.Errorf("reached level %d, want %d", level, engine.MaxDrillLevel)
+	}
+	total := 0
+	for _, stats := range g.DrillStats() {
+		total += stats.Asked
+	}
+	if total != asked {
+		t.Errorf("drill stats count %d answers about attributes, want %d", total, asked)
+	}
+}
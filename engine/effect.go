@@ -0,0 +1,114 @@
+package engine
+
+import "sort"
+
+// EffectKind identifies what a transient effect shows.
+type EffectKind int
+
+// Kinds of effects.
+const (
+	EffectSparkle EffectKind = iota // twinkles around a card
+	EffectFloat                     // a change in score floating upward
+	EffectFlash                     // a flash of the whole screen
+)
+
+// An Effect is a transient overlay drawn over the game for a number of steps.
+type Effect struct {
+	Kind  EffectKind // what the effect shows
+	Col   int        // the column the effect is anchored at
+	Row   int        // the row the effect is anchored at
+	Value int        // a value to show, such as a change in score
+	Steps int        // the number of steps the effect lasts
+	Step  int        // the current step of the effect
+}
+
+// Effects stores transient overlays separately from game state.
+type Effects struct {
+	effects   map[int]*Effect
+	nextIndex int
+	disabled  bool // whether effects are suppressed, as in reduced-motion mode
+}
+
+// NewEffects creates a new set of effects.
+func NewEffects() Effects {
+	return Effects{
+		effects: make(map[int]*Effect),
+	}
+}
+
+// Add an effect and use the given animator to advance it.
+func (e *Effects) Add(animator *Animator, effect Effect) {
+	if e.disabled {
+		return
+	}
+	index := e.nextIndex
+	e.nextIndex++
+	e.effects[index] = &effect
+	animator.Animate(Animation{
+		action: func(step int) bool {
+			if step >= effect.Steps {
+				delete(e.effects, index)
+				return false
+			}
+			effect.Step = step
+			return true
+		},
+	})
+}
+
+// List returns all running effects in the order they were added.
+func (e *Effects) List() []Effect {
+	indices := make([]int, 0, len(e.effects))
+	for i := range e.effects {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	list := make([]Effect, 0, len(indices))
+	for _, i := range indices {
+		list = append(list, *e.effects[i])
+	}
+	return list
+}
+
+// EFFECTS ********************************************************************
+
+// number of frames a sparkle lasts
+const sparkleSteps = 8
+
+// number of frames a floating score change lasts
+const floatSteps = 6
+
+// number of frames a screen flash lasts
+const flashSteps = 2
+
+// number of sets in a row it takes to flash the screen
+const streakFlashLength = 3
+
+// twinkle around the edges of a card at the given position
+func sparkleEffect(col int, row int) Effect {
+	return Effect{Kind: EffectSparkle, Col: col, Row: row, Steps: sparkleSteps}
+}
+
+// float a change in score upward from the given position
+func floatEffect(change int, col int, row int) Effect {
+	return Effect{Kind: EffectFloat, Col: col, Row: row, Value: change, Steps: floatSteps}
+}
+
+// briefly flash the whole frame
+func flashEffect() Effect {
+	return Effect{Kind: EffectFlash, Steps: flashSteps}
+}
+
+// get a point near the middle of a group of cards
+func cardsCenter(cards []*Card) (col int, row int) {
+	if len(cards) == 0 {
+		return
+	}
+	for _, card := range cards {
+		col += card.col
+		row += card.row
+	}
+	col = (col / len(cards)) + (CardWidth / 2)
+	row = (row / len(cards)) + (CardHeight / 2)
+	return
+}
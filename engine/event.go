@@ -0,0 +1,77 @@
+package engine
+
+import "time"
+
+// An Event describes something that happened in a game.
+type Event interface {
+	isEvent()
+}
+
+// A Subscriber is a function that receives events as they happen.
+type Subscriber = func(Event)
+
+// CardDealt is published when a card lands on the table.
+type CardDealt struct {
+	Card int // the id of the card
+	Slot int // the position on the table the card was dealt to
+}
+
+// CardSelected is published when the player selects or deselects a card.
+type CardSelected struct {
+	Card     int  // the id of the card
+	Slot     int  // the position of the card on the table
+	Selected bool // whether the card is now selected
+}
+
+// SetFound is published when the player selects cards that form a set.
+type SetFound struct {
+	Cards []int // the ids of the cards in the set
+}
+
+// InvalidSet is published when the player selects cards that don't form a set.
+type InvalidSet struct {
+	Cards []int // the ids of the cards selected
+}
+
+// TableExtended is published when extra cards are dealt because the table has no set.
+type TableExtended struct {
+	Size  int // the number of cards on the table once the extra cards are dealt
+	Added int // the number of extra cards dealt
+}
+
+// GameOver is published when no cards are left to deal and the table has no set,
+// or when every set in a puzzle has been found.
+type GameOver struct {
+	Score   int           // the final score
+	Elapsed time.Duration // the amount of game time the game took
+}
+
+func (CardDealt) isEvent()     {}
+func (CardSelected) isEvent()  {}
+func (SetFound) isEvent()      {}
+func (InvalidSet) isEvent()    {}
+func (TableExtended) isEvent() {}
+func (GameOver) isEvent()      {}
+
+// Subscribe adds a function that receives every event the game publishes.
+// Subscribers are called synchronously in the order they subscribed, and
+// events are delivered in the order they happen.
+func (g *Game) Subscribe(s Subscriber) {
+	g.subscribers = append(g.subscribers, s)
+}
+
+// send an event to all subscribers
+func (g *Game) publish(e Event) {
+	for _, s := range g.subscribers {
+		s(e)
+	}
+}
+
+// get the ids of a group of cards
+func cardIDs(cards []*Card) []int {
+	ids := make([]int, len(cards))
+	for i, card := range cards {
+		ids[i] = card.id
+	}
+	return ids
+}
@@ -0,0 +1,82 @@
+package engine_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// an event and the tick it was published at
+type tickEvent struct {
+	tick  int
+	event engine.Event
+}
+
+// play a seeded game to the end, making a mistake before each set, and
+// return every event published
+func playEvents(seed int64) []tickEvent {
+	g := engine.NewGame(seed)
+	tick := 0
+	events := make([]tickEvent, 0)
+	g.Subscribe(func(e engine.Event) {
+		events = append(events, tickEvent{tick, e})
+	})
+	for (tick < 100000) && !g.Over() {
+		if tick%150 == 149 {
+			g.Input('a')
+			g.Input('b')
+			g.Input('c')
+			selectFirstSet(g)
+		}
+		g.Step()
+		tick++
+	}
+	return events
+}
+
+func TestEventOrderIsDeterministic(t *testing.T) {
+	first := playEvents(1)
+	second := playEvents(1)
+	for i := 0; i < len(first) && i < len(second); i++ {
+		if !reflect.DeepEqual(first[i], second[i]) {
+			t.Fatalf("event %d is %+v in one play and %+v in another", i, first[i], second[i])
+		}
+	}
+	if len(first) != len(second) {
+		t.Fatalf("one play has %d events and another %d", len(first), len(second))
+	}
+	kinds := make(map[string]int)
+	for _, e := range first {
+		kinds[fmt.Sprintf("%T", e.event)]++
+	}
+	for _, want := range []engine.Event{engine.CardDealt{}, engine.CardSelected{}, engine.SetFound{},
+		engine.InvalidSet{}, engine.GameOver{}} {
+		if kinds[fmt.Sprintf("%T", want)] == 0 {
+			t.Errorf("the game published no %T", want)
+		}
+	}
+}
+
+func TestSubscribersAreCalledInOrder(t *testing.T) {
+	g := engine.NewGame(1)
+	calls := make([]int, 0)
+	for i := 0; i < 3; i++ {
+		subscriber := i
+		g.Subscribe(func(e engine.Event) {
+			calls = append(calls, subscriber)
+		})
+	}
+	for i := 0; i < 200; i++ {
+		g.Step()
+	}
+	if len(calls) == 0 {
+		t.Fatal("no events were published while dealing")
+	}
+	for i, subscriber := range calls {
+		if subscriber != i%3 {
+			t.Fatalf("call %d went to subscriber %d, want %d", i, subscriber, i%3)
+		}
+	}
+}
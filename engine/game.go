@@ -0,0 +1,560 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// TickDuration is the amount of game time that passes with each update.
+const TickDuration = 50 * time.Millisecond
+
+// PauseKey is the input character that pauses and resumes the game.
+const PauseKey = ' '
+
+// Game stores the complete state of a game in progress.
+type Game struct {
+	rules       Rules            // what the game is played with and what counts as a set
+	difficulty  Difficulty       // which sets cards are dealt to form
+	deck        Deck             // all cards in the game
+	table       [TableSize]*Card // cards currently dealt to the table
+	animator    Animator         // animations that modify game state
+	pauser      Animator         // animations that run while the game is paused
+	effects     Effects          // transient overlays that don't modify game state
+	needsRender bool             // whether game state has changed since the last render
+	random      rand.Source      // a source of randomness for the game
+	seed        int64            // the seed the source of randomness started from
+	draws       int              // the number of values drawn from the source of randomness
+	scoring     ScoringPolicy    // how selections change the score
+	points      Points           // the current player's score, broken down by where the points came from
+	lastSet     int              // the tick the last set was found at
+	puzzle      *Puzzle          // the puzzle being solved, or nil for an ordinary game
+	found       [][]int          // sorted card ids of each different set found in a puzzle
+	mistakes    int              // the number of selections that weren't sets
+	mode        mode             // how a drill or trainer is played, or nil for an ordinary game
+	setSizes    []int            // the number of cards in each set found, in order
+	streak      int              // the number of sets found since the last mistake
+	elapsed     int              // the number of ticks the game has been in play
+	paused      bool             // whether the game is paused
+	concealed   []*Card          // cards turned over to hide them while paused
+	over        bool             // whether the game has ended
+	subscribers []Subscriber     // functions that receive game events
+}
+
+// NewGame returns a game with initial state whose cards are dealt in an order determined by the seed.
+func NewGame(seed int64) *Game {
+	return NewGameWithRules(seed, ClassicRules{})
+}
+
+// NewGameWithRules returns a game like NewGame that is played by the given rules.
+func NewGameWithRules(seed int64, rules Rules) *Game {
+	return NewGameWithDifficulty(seed, rules, DifficultyNormal)
+}
+
+// NewGameWithDifficulty returns a game like NewGameWithRules that deals cards
+// to form sets suiting the difficulty level where it can. Check that the level
+// Supports the rules first, since other games deal cards as at normal.
+func NewGameWithDifficulty(seed int64, rules Rules, difficulty Difficulty) *Game {
+	g := newGame(seed, rules)
+	g.difficulty = difficulty
+	g.tidyTable()
+	return g
+}
+
+// make a game with no cards dealt
+func newGame(seed int64, rules Rules) *Game {
+	return &Game{
+		rules:       rules,
+		scoring:     ClassicScoring{},
+		deck:        rules.NewDeck(),
+		animator:    NewAnimator(),
+		pauser:      NewAnimator(),
+		effects:     NewEffects(),
+		needsRender: true,
+		random:      rand.NewSource(seed),
+		seed:        seed,
+	}
+}
+
+// SetReducedMotion turns transient visual effects off or on.
+func (g *Game) SetReducedMotion(reduced bool) {
+	g.effects.disabled = reduced
+}
+
+// Input updates the game state based on an input character.
+func (g *Game) Input(c rune) {
+	if g.CheckInput(c) != nil {
+		return
+	}
+	if c == PauseKey {
+		g.SetPaused(!g.paused)
+		return
+	}
+	if g.mode != nil {
+		g.mode.input(c)
+		return
+	}
+	// check for a set
+	if card := g.toggleCard(c); card.selected {
+		g.checkForSet()
+	}
+}
+
+// CheckInput returns an error describing why Input would ignore the given character, or nil.
+func (g *Game) CheckInput(c rune) error {
+	if c == PauseKey {
+		return nil
+	}
+	if g.mode != nil {
+		return g.mode.checkInput(c)
+	}
+	return g.checkCardInput(c)
+}
+
+// return an error if an input doesn't select a card that can be selected
+func (g *Game) checkCardInput(c rune) error {
+	tableIndex := slotForInput(c)
+	switch {
+	case tableIndex < 0:
+		return fmt.Errorf("unrecognized input %q", c)
+	case g.paused:
+		return fmt.Errorf("the game is paused")
+	case g.over:
+		return fmt.Errorf("the game is over")
+	case g.table[tableIndex] == nil:
+		return fmt.Errorf("position %c is empty", 'A'+tableIndex)
+	case g.table[tableIndex].layer != LayerDealt:
+		return fmt.Errorf("the card at position %c is still being dealt", 'A'+tableIndex)
+	}
+	return nil
+}
+
+// select or deselect the card an input selects, returning the card
+func (g *Game) toggleCard(c rune) *Card {
+	tableIndex := slotForInput(c)
+	card := g.table[tableIndex]
+	card.selected = !card.selected
+	g.needsRender = true
+	g.publish(CardSelected{Card: card.id, Slot: tableIndex, Selected: card.selected})
+	return card
+}
+
+// get the table position selected by an input character, or -1 if it doesn't select one
+func slotForInput(c rune) int {
+	if (c >= 'a') && (c < 'a'+TableSize) {
+		return int(c - 'a')
+	} else if (c >= 'A') && (c < 'A'+TableSize) {
+		return int(c - 'A')
+	}
+	return -1
+}
+
+// SetPaused stops or restarts the game clock and hides or shows the table.
+func (g *Game) SetPaused(paused bool) {
+	if paused == g.paused {
+		return
+	}
+	g.paused = paused
+	g.needsRender = true
+	// drop turns still running from the last toggle so they don't fight the new ones
+	g.pauser = NewAnimator()
+	if paused {
+		g.concealed = g.concealed[:0]
+		for _, card := range g.cardsInPlay() {
+			if (card != nil) && (card.layer == LayerDealt) {
+				g.concealed = append(g.concealed, card)
+				g.pauser.Animate(*concealAnimation(card))
+			}
+		}
+	} else {
+		for _, card := range g.concealed {
+			g.pauser.Animate(*revealAnimation(card))
+		}
+		g.concealed = g.concealed[:0]
+	}
+}
+
+// Seed returns the seed that determines the order cards are dealt in.
+func (g *Game) Seed() int64 {
+	return g.seed
+}
+
+// Rules returns the rules the game is played by.
+func (g *Game) Rules() Rules {
+	return g.rules
+}
+
+// Difficulty returns the difficulty level the game deals cards for.
+func (g *Game) Difficulty() Difficulty {
+	return g.difficulty
+}
+
+// Paused returns whether the game is paused.
+func (g *Game) Paused() bool {
+	return g.paused
+}
+
+// Over returns whether the game has ended.
+func (g *Game) Over() bool {
+	return g.over
+}
+
+// Cards returns all cards in the game, in order of their ids.
+func (g *Game) Cards() []Card {
+	return g.deck
+}
+
+// Table returns the cards dealt to each position on the table, with nil for empty positions.
+func (g *Game) Table() []*Card {
+	return g.table[:]
+}
+
+// Score returns the current player's score.
+func (g *Game) Score() int {
+	return g.points.Total()
+}
+
+// Elapsed returns the amount of game time that has passed while not paused.
+func (g *Game) Elapsed() time.Duration {
+	return time.Duration(g.elapsed) * TickDuration
+}
+
+// Remaining returns the number of cards left to deal.
+func (g *Game) Remaining() int {
+	return g.countCardsInLayer(LayerNotDealt)
+}
+
+// SetsCollected returns the number of sets that have been collected from the table.
+func (g *Game) SetsCollected() int {
+	// sets finish being collected in the order they were found
+	collected := g.countCardsInLayer(LayerCollected)
+	sets := 0
+	for _, size := range g.setSizes {
+		if collected < size {
+			break
+		}
+		collected -= size
+		sets++
+	}
+	return sets
+}
+
+// Effects returns the transient effects that should be drawn over the game.
+func (g *Game) Effects() []Effect {
+	return g.effects.List()
+}
+
+// Step advances the game by one tick and returns whether it needs to be rendered.
+func (g *Game) Step() bool {
+	// apply animations
+	if g.pauser.Step() {
+		g.needsRender = true
+	}
+	if !g.paused {
+		if g.animator.Step() {
+			g.needsRender = true
+		}
+	}
+	if !g.paused && !g.over {
+		// advance the clock and redraw it each second
+		g.elapsed++
+		if g.Elapsed()%time.Second == 0 {
+			g.needsRender = true
+		}
+	}
+	needsRender := g.needsRender
+	g.needsRender = false
+	return needsRender
+}
+
+// TABLE OPERATIONS ***********************************************************
+
+// number of frames it takes to deal a card
+const dealSteps = MaxShrink
+
+// number of frames it takes to collect a card
+const collectSteps = MaxShrink
+
+// animate dealing a card from the draw pile
+func (g *Game) dealAnimation(card *Card) *Animation {
+	// find the first empty spot on the table for the card
+	tableIndex := 0
+	for i := range g.table {
+		if g.table[i] == nil {
+			tableIndex = i
+			break
+		}
+	}
+	g.table[tableIndex] = card
+	col, row := TableCoords(tableIndex)
+	startCol, startRow := DrawPileCoords()
+	// ensure the card is invisible but not dealt twice
+	card.layer = LayerToDeal
+	return &Animation{
+		action: func(step int) bool {
+			if step == 0 {
+				card.selected = false
+				card.col = startCol
+				card.row = startRow
+				card.shrink = MaxShrink
+				card.turn = BackTurn
+				card.layer = LayerDealing
+			}
+			p := float32(step) / dealSteps
+			card.shrink = int(float32(MaxShrink) * (1.0 - p))
+			card.col = startCol + int(float32(col-startCol)*p)
+			card.row = startRow + int(float32(row-startRow)*p)
+			if step >= dealSteps {
+				card.layer = LayerDealt
+				g.publish(CardDealt{Card: card.id, Slot: tableIndex})
+				return false
+			}
+			return true
+		},
+	}
+}
+
+// animate a card being collected to the given location
+func collectAnimation(card *Card, col int, row int) *Animation {
+	card.layer = LayerCollecting
+	startCol := card.col
+	startRow := card.row
+	return &Animation{
+		action: func(step int) bool {
+			p := float32(step) / collectSteps
+			card.shrink = int(float32(MaxShrink) * p)
+			card.col = startCol + int(float32(col-startCol)*p)
+			card.row = startRow + int(float32(row-startRow)*p)
+			if step >= collectSteps {
+				card.layer = LayerCollected
+				return false
+			}
+			return true
+		},
+	}
+}
+
+// pick a random card that is not on the table
+func (g *Game) pickCard() *Card {
+	// iterate a limited number of times, just in case all cards are dealt
+	for tries := 0; tries <= 1000; tries++ {
+		i := g.draw() % int64(len(g.deck))
+		card := &g.deck[i]
+		if card.layer == LayerNotDealt {
+			return card
+		}
+	}
+	// fall back to the first card that hasn't been dealt
+	for i := range g.deck {
+		if g.deck[i].layer == LayerNotDealt {
+			return &g.deck[i]
+		}
+	}
+	return nil
+}
+
+// draw a value from the source of randomness, keeping track of how many were drawn
+func (g *Game) draw() int64 {
+	g.draws++
+	return g.random.Int63()
+}
+
+// deal a number of random cards onto the table
+func (g *Game) dealRandom(count int) *Animation {
+	count = min(count, g.countCardsInLayer(LayerNotDealt))
+	return g.dealCards(count, g.pickTableCard)
+}
+
+// deal a number of cards onto the table, picking each one as it's dealt
+func (g *Game) dealCards(count int, pick func() *Card) *Animation {
+	if count <= 0 {
+		return nil
+	}
+	var firstAnimation *Animation
+	var lastAnimation *Animation
+	for i := 0; i < count; i++ {
+		if firstAnimation == nil {
+			firstAnimation = g.dealAnimation(pick())
+			lastAnimation = firstAnimation
+		} else {
+			animation := g.dealAnimation(pick())
+			lastAnimation.andThen = animation
+			lastAnimation = animation
+		}
+	}
+	lastAnimation.andThen = &Animation{
+		action: func(step int) bool {
+			g.revealAll()
+			g.checkForCap()
+			return false
+		},
+	}
+	g.animator.Animate(*firstAnimation)
+	// return the final animation for chaining
+	return lastAnimation
+}
+
+// animate flipping the given card over to reveal its front
+func revealAnimation(card *Card) *Animation {
+	return turnAnimation(card, FrontTurn)
+}
+
+// animate flipping the given card over to hide its front
+func concealAnimation(card *Card) *Animation {
+	return turnAnimation(card, BackTurn)
+}
+
+// animate turning the given card until it reaches the given turn
+func turnAnimation(card *Card, turn int) *Animation {
+	return &Animation{
+		action: func(step int) bool {
+			if card.turn < turn {
+				card.turn++
+				return true
+			} else if card.turn > turn {
+				card.turn--
+				return true
+			} else {
+				return false
+			}
+		},
+	}
+}
+
+// reveal all cards on the table
+func (g *Game) revealAll() {
+	for _, card := range g.table {
+		if (card != nil) && (card.turn != FrontTurn) {
+			g.animator.Animate(*revealAnimation(card))
+		}
+	}
+}
+
+// check to see whether the user has selected a set
+func (g *Game) checkForSet() {
+	// check if enough cards are selected, or if any number will do, whether they're a set
+	selected := g.selectedCards()
+	size := g.rules.SelectionSize()
+	if (len(selected) == size) || ((size == 0) && g.rules.IsSet(selected)) {
+		if g.puzzle != nil {
+			g.checkForPuzzleSet(selected)
+			return
+		}
+		centerCol, centerRow := cardsCenter(selected)
+		isSet := g.rules.IsSet(selected)
+		change := g.scoreSelection(selected, isSet)
+		if isSet {
+			// the cards are a set, collect them
+			col, row := CollectedPileCoords()
+			for _, card := range selected {
+				g.effects.Add(&g.animator, sparkleEffect(card.col, card.row))
+				g.removeCardFromTable(card)
+				g.animator.Animate(*collectAnimation(card, col, row))
+			}
+			g.setSizes = append(g.setSizes, len(selected))
+			g.streak++
+			g.effects.Add(&g.animator, floatEffect(change, centerCol, centerRow))
+			if g.streak >= streakFlashLength {
+				g.effects.Add(&g.animator, flashEffect())
+			}
+			g.publish(SetFound{Cards: cardIDs(selected)})
+			g.tidyTable()
+		} else {
+			// the cards are not a set, let them go
+			g.mistakes++
+			g.streak = 0
+			g.effects.Add(&g.animator, floatEffect(change, centerCol, centerRow))
+			g.publish(InvalidSet{Cards: cardIDs(selected)})
+			for _, card := range selected {
+				card.selected = false
+			}
+		}
+	}
+}
+
+// deal and consolidate cards
+func (g *Game) tidyTable() {
+	if (g.puzzle != nil) || (g.mode != nil) {
+		return
+	}
+	dealt := g.countCardsDealt()
+	if base := g.rules.BaseTableSize(); dealt < base {
+		// check for caps once the new cards have been dealt
+		if g.dealRandom(base-dealt) != nil {
+			return
+		}
+	}
+	g.checkForCap()
+	// TODO: consolidate cards
+}
+
+// deal extra cards if the table has no set, or end the game if none are left
+func (g *Game) checkForCap() {
+	if g.over || (g.puzzle != nil) || (g.mode != nil) {
+		return
+	}
+	extend := g.rules.ExtendSize(g.table[:])
+	if extend <= 0 {
+		return
+	}
+	dealt := g.countCardsDealt()
+	if (g.Remaining() > 0) && (dealt < TableSize) {
+		g.dealRandom(min(extend, TableSize-dealt))
+		g.publish(TableExtended{Size: g.countCardsDealt(), Added: g.countCardsDealt() - dealt})
+	} else {
+		g.over = true
+		g.needsRender = true
+		g.publish(GameOver{Score: g.Score(), Elapsed: g.Elapsed()})
+	}
+}
+
+// count cards on the table, including any still being dealt
+func (g *Game) countCardsDealt() int {
+	count := 0
+	for _, card := range g.table {
+		if card != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// count cards in the deck with the given layer
+func (g *Game) countCardsInLayer(layer int) int {
+	count := 0
+	for i := range g.deck {
+		if g.deck[i].layer == layer {
+			count++
+		}
+	}
+	return count
+}
+
+// remove a card from the table
+func (g *Game) removeCardFromTable(removeCard *Card) {
+	for i, card := range g.table {
+		if card == removeCard {
+			g.table[i] = nil
+		}
+	}
+}
+
+// get the cards on the table and any others the player is shown
+func (g *Game) cardsInPlay() []*Card {
+	cards := g.table[:]
+	if g.mode != nil {
+		cards = append(cards[:len(cards):len(cards)], g.mode.shown()...)
+	}
+	return cards
+}
+
+// get all selected cards
+func (g *Game) selectedCards() []*Card {
+	selected := make([]*Card, 0, len(g.table))
+	for _, card := range g.table {
+		if (card != nil) && (card.selected) {
+			selected = append(selected, card)
+		}
+	}
+	return selected
+}
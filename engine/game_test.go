@@ -0,0 +1,48 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// make a game with every card dealt and turned face up
+func dealtGame(seed int64) *engine.Game {
+	g := engine.NewGame(seed)
+	for i := 0; i < 200; i++ {
+		g.Step()
+	}
+	return g
+}
+
+func TestPauseToggledQuickly(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		toggles int
+		turn    int
+	}{
+		{"paused", 1, engine.BackTurn},
+		{"resumed", 2, engine.FrontTurn},
+		{"paused again", 3, engine.BackTurn},
+		{"resumed again", 4, engine.FrontTurn},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			g := dealtGame(1)
+			for i := 0; i < c.toggles; i++ {
+				g.SetPaused(!g.Paused())
+				g.Step()
+			}
+			for i := 0; i < 2*engine.BackTurn; i++ {
+				g.Step()
+			}
+			for i, card := range g.Table() {
+				if (card != nil) && (card.Turn() != c.turn) {
+					t.Errorf("card at %d has turn %d, want %d", i, card.Turn(), c.turn)
+				}
+			}
+			if g.Paused() && g.Step() {
+				t.Errorf("paused game still needs rendering after its cards stopped turning")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package engine
+
+// CardWidth is the width of a rendered card in characters.
+const CardWidth = 5
+
+// CardHeight is the height of a rendered card in lines.
+const CardHeight = 5
+
+// TableSize is the maximum number of cards dealt onto the table at one time.
+const TableSize = 21
+
+// TableCoords returns the card coordinates for the given index in the table.
+func TableCoords(i int) (col int, row int) {
+	row = (i % 3) * CardHeight
+	col = 1 + ((i / 3) * (CardWidth + 2))
+	return
+}
+
+// DrawPileCoords returns the coordinates of the pile of cards left to deal.
+func DrawPileCoords() (col int, row int) {
+	col = 1
+	row = (CardHeight * 3) + 1
+	return
+}
+
+// CollectedPileCoords returns the coordinates of the pile of collected sets.
+func CollectedPileCoords() (col int, row int) {
+	col, row = DrawPileCoords()
+	col += 2 * (CardWidth + 2)
+	return
+}
@@ -0,0 +1,13 @@
+package engine
+
+// A mode plays a game differently from an ordinary one, like a drill that
+// asks questions about cards instead of dealing a table, so the game can hand
+// over to it instead of checking for each kind of practice.
+type mode interface {
+	// checkInput returns an error describing why the mode would ignore an input, or nil.
+	checkInput(c rune) error
+	// input acts on an input the mode accepts.
+	input(c rune)
+	// shown returns the cards the player is shown besides those on the table.
+	shown() []*Card
+}
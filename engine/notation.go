@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Card notation writes a card as its count followed by letters for its
+// color, fill and shape, so "2RSo" is two red striped ovals. The letters
+// follow the printed deck, with aliases for how the cards look on screen.
+
+// the letters for each value of an attribute, with the first used when formatting
+var (
+	colorLetters = [3]string{"R", "G", "PB"}  // red, green, purple or blue
+	fillLetters  = [3]string{"OE", "S", "F"}  // open or empty, striped, filled
+	shapeLetters = [3]string{"dt", "s", "oc"} // diamond or triangle, squiggle or square, oval or circle
+)
+
+// Notation returns the card in card notation, like "2RSo".
+func (c *Card) Notation() string {
+	count, shape, fill, clr := c.Attributes()
+	return fmt.Sprintf("%d%c%c%c", count,
+		colorLetters[clr][0], fillLetters[fill][0], shapeLetters[shape][0])
+}
+
+// ParseCard returns the card written in card notation, ignoring case.
+func ParseCard(s string) (Card, error) {
+	if len(s) != 4 {
+		return Card{}, fmt.Errorf("invalid card %q: expecting a count, color, fill and shape like 2RSo", s)
+	}
+	count := int(s[0] - '1')
+	if (count < 0) || (count > 2) {
+		return Card{}, fmt.Errorf("invalid card %q: count must be 1, 2 or 3", s)
+	}
+	clr := parseLetter(s[1], colorLetters)
+	if clr < 0 {
+		return Card{}, fmt.Errorf("invalid card %q: color must be R, G or P", s)
+	}
+	fill := parseLetter(s[2], fillLetters)
+	if fill < 0 {
+		return Card{}, fmt.Errorf("invalid card %q: fill must be O, S or F", s)
+	}
+	shape := parseLetter(s[3], shapeLetters)
+	if shape < 0 {
+		return Card{}, fmt.Errorf("invalid card %q: shape must be d, s or o", s)
+	}
+	return NewCard(count + (shape * 3) + (fill * 9) + (clr * 27)), nil
+}
+
+// find which value of an attribute a letter stands for, or -1 if none
+func parseLetter(letter byte, letters [3]string) int {
+	for value, options := range letters {
+		if strings.ContainsRune(strings.ToLower(options), rune(letter|0x20)) {
+			return value
+		}
+	}
+	return -1
+}
@@ -0,0 +1,65 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+func TestNotationRoundTrip(t *testing.T) {
+	for _, card := range engine.NewDeck() {
+		parsed, err := engine.ParseCard(card.Notation())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.ID() != card.ID() {
+			t.Errorf("%s parsed as card %d, want %d", card.Notation(), parsed.ID(), card.ID())
+		}
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	for _, c := range []struct {
+		text     string
+		notation string
+	}{
+		{"2RSo", "2RSo"},
+		{"2rsO", "2RSo"},
+		{"1ROd", "1ROd"},
+		{"1REt", "1ROd"},
+		{"1reT", "1ROd"},
+		{"3GFs", "3GFs"},
+		{"3PFc", "3PFo"},
+		{"3BFc", "3PFo"},
+		{"3bfC", "3PFo"},
+		{"2Pod", "2POd"},
+	} {
+		card, err := engine.ParseCard(c.text)
+		if err != nil {
+			t.Errorf("%s: %v", c.text, err)
+		} else if card.Notation() != c.notation {
+			t.Errorf("%s parsed as %s, want %s", c.text, card.Notation(), c.notation)
+		}
+	}
+}
+
+func TestParseCardErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"2RS",
+		"2RSoo",
+		"0RSo",
+		"4RSo",
+		"xRSo",
+		"2XSo",
+		"2RXo",
+		"2RSx",
+		"2SRo",
+		"2R So",
+		"２RSo",
+	} {
+		if _, err := engine.ParseCard(text); err == nil {
+			t.Errorf("%q parsed without an error", text)
+		}
+	}
+}
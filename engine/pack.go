@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// PackVersion is the version of the puzzle pack format written by Pack.Write.
+const PackVersion = 1
+
+// A Pack is a collection of puzzles to play in order.
+type Pack struct {
+	Name    string   // a name for the collection
+	Puzzles []Puzzle // the puzzles in the order they're played
+}
+
+// the serialized form of a puzzle pack
+type savedPack struct {
+	Version int           `json:"version"`
+	Name    string        `json:"name"`
+	Puzzles []savedPuzzle `json:"puzzles"`
+}
+
+// the serialized form of a puzzle in a pack
+type savedPuzzle struct {
+	Name       string  `json:"name"`
+	Seed       int64   `json:"seed"`
+	Difficulty float64 `json:"difficulty"` // the mean number of attributes that differ within each set
+	Sets       int     `json:"sets"`       // the number of sets to find
+	Cards      []int   `json:"cards"`      // card ids in table order
+}
+
+// Write the pack as JSON, including metadata about each puzzle.
+func (p *Pack) Write(w io.Writer) error {
+	s := savedPack{
+		Version: PackVersion,
+		Name:    p.Name,
+		Puzzles: make([]savedPuzzle, len(p.Puzzles)),
+	}
+	for i := range p.Puzzles {
+		puzzle := &p.Puzzles[i]
+		s.Puzzles[i] = savedPuzzle{
+			Name:       puzzle.Name,
+			Seed:       puzzle.Seed,
+			Difficulty: puzzle.Difficulty(),
+			Sets:       puzzle.Sets(),
+			Cards:      puzzle.Cards,
+		}
+	}
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReadPack reads a pack written by Pack.Write, checking that each puzzle has
+// the cards and sets it claims.
+func ReadPack(r io.Reader) (Pack, error) {
+	var s savedPack
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return Pack{}, err
+	}
+	if s.Version != PackVersion {
+		return Pack{}, fmt.Errorf("unsupported pack version %d", s.Version)
+	}
+	p := Pack{Name: s.Name, Puzzles: make([]Puzzle, len(s.Puzzles))}
+	deckSize := len(NewDeck())
+	for i, saved := range s.Puzzles {
+		if len(saved.Cards) > TableSize {
+			return Pack{}, fmt.Errorf("puzzle %q has %d cards, more than %d", saved.Name, len(saved.Cards), TableSize)
+		}
+		seen := make(map[int]bool)
+		for _, id := range saved.Cards {
+			if (id < 0) || (id >= deckSize) || seen[id] {
+				return Pack{}, fmt.Errorf("puzzle %q has an invalid card %d", saved.Name, id)
+			}
+			seen[id] = true
+		}
+		p.Puzzles[i] = Puzzle{Name: saved.Name, Seed: saved.Seed, Cards: saved.Cards}
+		if sets := p.Puzzles[i].Sets(); sets != saved.Sets {
+			return Pack{}, fmt.Errorf("puzzle %q claims %d sets but has %d", saved.Name, saved.Sets, sets)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,82 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// write a pack with one puzzle to a string
+func writtenPack(t *testing.T) string {
+	t.Helper()
+	p, err := engine.NewPuzzle("first", 1, engine.PuzzleSize, engine.PuzzleSets, engine.DifficultyNormal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := (&engine.Pack{Name: "test", Puzzles: []engine.Puzzle{p}}).Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestPackRoundTrip(t *testing.T) {
+	written := writtenPack(t)
+	p, err := engine.ReadPack(strings.NewReader(written))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := p.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != written {
+		t.Errorf("wrote back\n%s\nwant\n%s", b.String(), written)
+	}
+}
+
+func TestReadPackErrors(t *testing.T) {
+	written := writtenPack(t)
+	puzzle := func(key string, value interface{}) string {
+		var pack map[string]interface{}
+		if err := json.Unmarshal([]byte(written), &pack); err != nil {
+			t.Fatal(err)
+		}
+		pack["puzzles"].([]interface{})[0].(map[string]interface{})[key] = value
+		changed, err := json.Marshal(pack)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(changed)
+	}
+	tooMany := make([]int, engine.TableSize+1)
+	for i := range tooMany {
+		tooMany[i] = i
+	}
+	cases := []struct {
+		name string
+		pack string
+	}{
+		{"empty", ""},
+		{"not json", "puzzles"},
+		{"bad version", strings.Replace(written, `"version":1`, `"version":2`, 1)},
+		{"too many cards", puzzle("cards", tooMany)},
+		{"invalid card", puzzle("cards", []int{0, 1, 81})},
+		{"negative card", puzzle("cards", []int{-1, 0, 1})},
+		{"card twice", puzzle("cards", []int{0, 1, 1})},
+		{"wrong sets", puzzle("sets", engine.PuzzleSets+1)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if p, err := engine.ReadPack(strings.NewReader(c.pack)); err == nil {
+				t.Errorf("read %v, want an error", p)
+			} else if !reflect.DeepEqual(p, engine.Pack{}) {
+				t.Errorf("read %v along with error %v, want nothing", p, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package engine
+
+// ProSetDots is the number of dot colors in a ProSET deck.
+const ProSetDots = 6
+
+// NewProSetDeck creates a complete ProSET deck of 63 cards, one for each
+// non-empty combination of dots, where the card with id i shows the dots in
+// the bits of i+1.
+func NewProSetDeck() Deck {
+	d := make(Deck, (1<<ProSetDots)-1)
+	for id := range d {
+		d[id].id = id
+		d[id].dots = id + 1
+	}
+	return d
+}
+
+// ProSetRules play ProSET, where any number of cards form a set if every dot
+// color appears on an even number of them. Seven cards always hold a set, so
+// the table only grows when the last few cards hold none.
+type ProSetRules struct {
+	ClassicRules
+}
+
+// number of cards on a ProSET table
+const proSetTableSize = 7
+
+// Name returns "proset".
+func (ProSetRules) Name() string {
+	return "proset"
+}
+
+// NewDeck creates a complete ProSET deck.
+func (ProSetRules) NewDeck() Deck {
+	return NewProSetDeck()
+}
+
+// SelectionSize returns 0, since a set can have any number of cards.
+func (ProSetRules) SelectionSize() int {
+	return 0
+}
+
+// IsSet returns whether every dot color appears on an even number of the cards.
+func (ProSetRules) IsSet(cards []*Card) bool {
+	parity := 0
+	for _, card := range cards {
+		parity ^= card.dots
+	}
+	return (len(cards) > 0) && (parity == 0)
+}
+
+// Score gives a point for each set, since ProSET cards don't have attributes
+// to differ in. Cards are only claimed once they form a set, so there are no
+// mistakes to take points away for.
+func (ProSetRules) Score(cards []*Card, isSet bool) Points {
+	return Points{Base: 1}
+}
+
+// BaseTableSize returns 7.
+func (ProSetRules) BaseTableSize() int {
+	return proSetTableSize
+}
+
+// ExtendSize returns 1 if no cards on the table form a set.
+func (ProSetRules) ExtendSize(table []*Card) int {
+	if hasEvenParitySubset(table) {
+		return 0
+	}
+	return 1
+}
+
+// return whether some of the given cards have dots with even parity, which is
+// the case when their dots are linearly dependent as vectors of bits
+func hasEvenParitySubset(cards []*Card) bool {
+	var basis [ProSetDots]int // basis vectors indexed by their highest bit
+	for _, card := range cards {
+		if card == nil {
+			continue
+		}
+		v := card.dots
+		for bit := ProSetDots - 1; (bit >= 0) && (v != 0); bit-- {
+			if v&(1<<bit) == 0 {
+				continue
+			}
+			if basis[bit] == 0 {
+				basis[bit] = v
+				break
+			}
+			v ^= basis[bit]
+		}
+		if v == 0 {
+			return true
+		}
+	}
+	return false
+}
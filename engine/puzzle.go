@@ -0,0 +1,240 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/jessecrossen/go81/solver"
+)
+
+// PuzzleSize is the number of cards on the table in a puzzle.
+const PuzzleSize = 12
+
+// PuzzleSets is the number of sets in a daily puzzle.
+const PuzzleSets = 6
+
+// A Puzzle is a fixed table of cards where the player finds every set,
+// with sets left on the table after they're found.
+type Puzzle struct {
+	Name  string // a name to share the puzzle by, like its date
+	Seed  int64  // the seed the puzzle was made from
+	Cards []int  // the ids of the cards on the table, in table order
+}
+
+// the number of random tables to try before giving up on making a puzzle
+const maxPuzzleTries = 1000000
+
+// the number of tables to try building before giving up on making a puzzle
+// for a difficulty level other than normal
+const maxPuzzleBuilds = 10000
+
+// the number of cards to try adding for each card on a table being built
+const puzzleBuildAttempts = 20
+
+// NewPuzzle makes a puzzle of classic cards with the given number of cards
+// and sets that all suit the difficulty level, picking the cards in an order
+// determined by the seed. It returns an error if no such table turns up after
+// many tries.
+func NewPuzzle(name string, seed int64, size int, sets int, difficulty Difficulty) (Puzzle, error) {
+	deckSize := len(NewDeck())
+	if (size < 0) || (size > min(deckSize, TableSize)) {
+		return Puzzle{}, fmt.Errorf("puzzles can't have %d cards", size)
+	}
+	random := rand.New(rand.NewSource(seed))
+	if difficulty == DifficultyNormal {
+		for tries := 0; tries < maxPuzzleTries; tries++ {
+			cards := random.Perm(deckSize)[:size]
+			if solver.Count(cards) == sets {
+				return Puzzle{Name: name, Seed: seed, Cards: cards}, nil
+			}
+		}
+	} else {
+		// random tables rarely have only sets that suit other levels, so build them up from such sets
+		for tries := 0; tries < maxPuzzleBuilds; tries++ {
+			if cards := buildPuzzleTable(random, deckSize, size, sets, difficulty); cards != nil {
+				return Puzzle{Name: name, Seed: seed, Cards: cards}, nil
+			}
+		}
+	}
+	return Puzzle{}, fmt.Errorf("found no table of %d cards with %d %s sets", size, sets, difficulty)
+}
+
+// DailyPuzzle returns the puzzle for the given day, which is the same for
+// everyone who plays it, or an error if no table with enough sets turns up.
+func DailyPuzzle(date time.Time) (Puzzle, error) {
+	year, month, day := date.Date()
+	seed := int64((year * 10000) + (int(month) * 100) + day)
+	return NewPuzzle(date.Format("2006-01-02"), seed, PuzzleSize, PuzzleSets, DifficultyNormal)
+}
+
+// Sets returns the number of sets there are to find in the puzzle.
+func (p *Puzzle) Sets() int {
+	return solver.Count(p.Cards)
+}
+
+// Difficulty returns the mean number of attributes that differ between the
+// cards of each set in the puzzle, from 1 to 4, where sets with more
+// differences are harder to spot.
+func (p *Puzzle) Difficulty() float64 {
+	sets := solver.Sets(p.Cards)
+	if len(sets) == 0 {
+		return 0
+	}
+	total := 0
+	for _, set := range sets {
+		total += DifferingAttributes(newCards(p.Cards[set[0]], p.Cards[set[1]]))
+	}
+	return float64(total) / float64(len(sets))
+}
+
+// NewPuzzleGame returns a game that deals the puzzle's cards in order and
+// ends once every set is found, without dealing more cards.
+func NewPuzzleGame(p Puzzle) *Game {
+	g := newGame(0, ClassicRules{})
+	g.puzzle = &p
+	// cards outside the puzzle are out of play
+	for i := range g.deck {
+		g.deck[i].layer = LayerCollected
+	}
+	cards := make([]*Card, 0, len(p.Cards))
+	for _, id := range p.Cards {
+		if (id >= 0) && (id < len(g.deck)) && (g.deck[id].layer == LayerCollected) {
+			g.deck[id].layer = LayerNotDealt
+			cards = append(cards, &g.deck[id])
+		}
+	}
+	next := 0
+	g.dealCards(min(len(cards), TableSize), func() *Card {
+		next++
+		return cards[next-1]
+	})
+	return g
+}
+
+// Puzzle returns the puzzle being solved, or nil for an ordinary game.
+func (g *Game) Puzzle() *Puzzle {
+	return g.puzzle
+}
+
+// FoundSets returns the card ids of each different set found so far in a
+// puzzle, in the order they were found.
+func (g *Game) FoundSets() [][]int {
+	return g.found
+}
+
+// Mistakes returns the number of times the player selected cards that weren't a set.
+func (g *Game) Mistakes() int {
+	return g.mistakes
+}
+
+// IMPLEMENTATION *************************************************************
+
+// check a selection in a puzzle, leaving the cards on the table and ignoring sets already found
+func (g *Game) checkForPuzzleSet(selected []*Card) {
+	for _, card := range selected {
+		card.selected = false
+	}
+	g.needsRender = true
+	ids := cardIDs(selected)
+	sort.Ints(ids)
+	isSet := g.rules.IsSet(selected)
+	if isSet && g.hasFound(ids) {
+		return
+	}
+	centerCol, centerRow := cardsCenter(selected)
+	change := g.scoreSelection(selected, isSet)
+	g.effects.Add(&g.animator, floatEffect(change, centerCol, centerRow))
+	if !isSet {
+		g.mistakes++
+		g.streak = 0
+		g.publish(InvalidSet{Cards: ids})
+		return
+	}
+	for _, card := range selected {
+		g.effects.Add(&g.animator, sparkleEffect(card.col, card.row))
+	}
+	g.found = append(g.found, ids)
+	g.streak++
+	g.publish(SetFound{Cards: ids})
+	if len(g.found) >= g.puzzle.Sets() {
+		g.over = true
+		g.publish(GameOver{Score: g.Score(), Elapsed: g.Elapsed()})
+	}
+}
+
+// try to build a table by adding sets that suit the difficulty level until
+// it has enough, then cards that form no more sets, returning nil on failure
+func buildPuzzleTable(random *rand.Rand, deckSize int, size int, sets int, difficulty Difficulty) []int {
+	cards := make([]int, 0, size)
+	for attempt := 0; (attempt < size*puzzleBuildAttempts) && (len(cards) < size); attempt++ {
+		added := []int{random.Intn(deckSize)}
+		if solver.Count(cards) < sets {
+			// complete a set from a card on the table or a new one
+			a, b := added[0], random.Intn(deckSize)
+			if (len(cards) > 0) && (random.Intn(2) == 0) {
+				a = cards[random.Intn(len(cards))]
+			}
+			if (a == b) || !difficulty.Allows(DifferingAttributes(newCards(a, b))) {
+				continue
+			}
+			added = []int{a, b, solver.Third(a, b)}
+		}
+		table := append([]int(nil), cards...)
+		for _, id := range added {
+			if !containsInt(table, id) {
+				table = append(table, id)
+			}
+		}
+		if (len(table) > len(cards)) && (len(table) <= size) &&
+			(solver.Count(table) <= sets) && suitsDifficulty(table, difficulty) {
+			cards = table
+		}
+	}
+	if (len(cards) != size) || (solver.Count(cards) != sets) {
+		return nil
+	}
+	// deal the cards in a random order so the sets they were built from don't show
+	random.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+	return cards
+}
+
+// return whether every set among the given card ids suits the difficulty level
+func suitsDifficulty(ids []int, difficulty Difficulty) bool {
+	for _, set := range solver.Sets(ids) {
+		if !difficulty.Allows(DifferingAttributes(newCards(ids[set[0]], ids[set[1]]))) {
+			return false
+		}
+	}
+	return true
+}
+
+// make classic cards with the given ids
+func newCards(ids ...int) []*Card {
+	cards := make([]*Card, len(ids))
+	for i, id := range ids {
+		card := NewCard(id)
+		cards[i] = &card
+	}
+	return cards
+}
+
+// return whether a set with the given sorted card ids was already found
+func (g *Game) hasFound(ids []int) bool {
+	for _, found := range g.found {
+		if len(found) != len(ids) {
+			continue
+		}
+		same := true
+		for i := range found {
+			same = same && (found[i] == ids[i])
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package engine_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+func TestNewPuzzleSuitsDifficulty(t *testing.T) {
+	for _, c := range []struct {
+		difficulty engine.Difficulty
+		size       int
+		sets       int
+	}{
+		{engine.DifficultyNormal, engine.PuzzleSize, engine.PuzzleSets},
+		{engine.DifficultyEasy, engine.PuzzleSize, engine.PuzzleSets},
+		{engine.DifficultyHard, engine.PuzzleSize, engine.PuzzleSets},
+		{engine.DifficultyEasy, 9, 0},
+		{engine.DifficultyHard, 9, 2},
+		{engine.DifficultyHard, 15, 10},
+	} {
+		for seed := int64(1); seed <= 10; seed++ {
+			p, err := engine.NewPuzzle("test", seed, c.size, c.sets, c.difficulty)
+			if err != nil {
+				t.Errorf("seed %d: %v", seed, err)
+				continue
+			}
+			if (len(p.Cards) != c.size) || (p.Sets() != c.sets) {
+				t.Errorf("seed %d: made a %s puzzle of %d cards with %d sets, want %d with %d",
+					seed, c.difficulty, len(p.Cards), p.Sets(), c.size, c.sets)
+			}
+			for _, set := range solver.Sets(p.Cards) {
+				a, b := engine.NewCard(p.Cards[set[0]]), engine.NewCard(p.Cards[set[1]])
+				if differing := engine.DifferingAttributes([]*engine.Card{&a, &b}); !c.difficulty.Allows(differing) {
+					t.Errorf("seed %d: %s puzzle has a set differing in %d attributes", seed, c.difficulty, differing)
+				}
+			}
+		}
+	}
+}
+
+func TestDailyPuzzle(t *testing.T) {
+	day := time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)
+	p, err := engine.DailyPuzzle(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (p.Name != "2024-03-09") || (len(p.Cards) != engine.PuzzleSize) || (p.Sets() != engine.PuzzleSets) {
+		t.Errorf("made puzzle %q of %d cards with %d sets, want 2024-03-09 with %d and %d",
+			p.Name, len(p.Cards), p.Sets(), engine.PuzzleSize, engine.PuzzleSets)
+	}
+	again, err := engine.DailyPuzzle(day.Add(20 * time.Hour))
+	if (err != nil) || !reflect.DeepEqual(again, p) {
+		t.Errorf("made %v, %v later the same day, want %v", again, err, p)
+	}
+}
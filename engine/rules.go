@@ -0,0 +1,173 @@
+package engine
+
+import "github.com/jessecrossen/go81/solver"
+
+// Rules decide what a game is played with and what counts as a set, so
+// variants can change them without changing how a Game runs.
+type Rules interface {
+	// Name returns the name of the variant the rules are for, as used in Variants.
+	Name() string
+	// NewDeck returns every card in the game, where each card's id is its index.
+	NewDeck() Deck
+	// SelectionSize returns the number of cards the player selects to claim a set,
+	// or 0 if sets can have any number of cards and are claimed as soon as they're selected.
+	SelectionSize() int
+	// IsSet returns whether the selected cards form a set.
+	IsSet(cards []*Card) bool
+	// BaseTableSize returns the number of cards to fill the table to after collecting a set.
+	BaseTableSize() int
+	// ExtendSize returns how many cards to add to a table with no set left on it,
+	// or 0 if the table has a set and play can continue.
+	ExtendSize(table []*Card) int
+	// Score returns the points for selecting cards that do or don't form a set,
+	// which scoring policies can add to.
+	Score(cards []*Card, isSet bool) Points
+}
+
+// ClassicRules are the rules of the standard game, with 81 cards of four
+// attributes, sets of three, and a table of 12 extended three cards at a time.
+// Variants can embed it to change only some of the rules.
+type ClassicRules struct{}
+
+// Variants holds the built-in rules by name.
+var Variants = map[string]Rules{
+	"classic": ClassicRules{},
+	"junior":  JuniorRules,
+	"ultra":   UltraRules{},
+	"proset":  ProSetRules{},
+}
+
+// number of cards the table is normally filled to
+const baseTableSize = 12
+
+// number of extra cards to deal when the table has no set
+const extendSize = 3
+
+// Name returns "classic".
+func (ClassicRules) Name() string {
+	return "classic"
+}
+
+// NewDeck creates a complete deck of 81 cards.
+func (ClassicRules) NewDeck() Deck {
+	return NewDeck()
+}
+
+// SelectionSize returns 3, since sets have three cards.
+func (ClassicRules) SelectionSize() int {
+	return 3
+}
+
+// IsSet returns whether three cards form a set.
+func (ClassicRules) IsSet(cards []*Card) bool {
+	return (len(cards) == 3) && AreSet(cards[0], cards[1], cards[2])
+}
+
+// BaseTableSize returns 12.
+func (ClassicRules) BaseTableSize() int {
+	return baseTableSize
+}
+
+// ExtendSize returns 3 if no three cards on the table form a set.
+func (ClassicRules) ExtendSize(table []*Card) int {
+	if solver.HasSet(TableIDs(table)) {
+		return 0
+	}
+	return extendSize
+}
+
+// Score gives a point for each set, with a bonus point for each attribute
+// beyond two that differs between its cards, and takes one away for each mistake.
+func (ClassicRules) Score(cards []*Card, isSet bool) Points {
+	if isSet {
+		return Points{Base: 1, Bonus: max(0, DifferingAttributes(cards)-2)}
+	}
+	return Points{Penalty: -1}
+}
+
+// DeckRules play like the classic game with a different deck, where a set
+// has as many cards as each attribute has values.
+type DeckRules struct {
+	ClassicRules
+	Variant string   // the name of the variant
+	Spec    DeckSpec // the deck to play with
+	Table   int      // the number of cards to fill the table to
+}
+
+// JuniorRules play with the 27-card junior deck on a table of 9 cards.
+var JuniorRules = DeckRules{Variant: "junior", Spec: JuniorDeck, Table: 9}
+
+// Name returns the name of the variant.
+func (r DeckRules) Name() string {
+	return r.Variant
+}
+
+// NewDeck creates a complete deck following the spec.
+func (r DeckRules) NewDeck() Deck {
+	return r.Spec.NewDeck()
+}
+
+// SelectionSize returns the number of values each attribute takes.
+func (r DeckRules) SelectionSize() int {
+	return r.Spec.Values
+}
+
+// IsSet returns whether the cards form a set in the deck.
+func (r DeckRules) IsSet(cards []*Card) bool {
+	return r.Spec.IsSet(cards)
+}
+
+// BaseTableSize returns the size of the table.
+func (r DeckRules) BaseTableSize() int {
+	return r.Table
+}
+
+// ExtendSize returns the size of a set if no cards on the table form one.
+func (r DeckRules) ExtendSize(table []*Card) int {
+	if r.Spec.HasSet(table) {
+		return 0
+	}
+	return r.Spec.Values
+}
+
+// UltraRules play like the classic game, except the player selects four cards
+// that split into two pairs the same missing card would complete to a set.
+type UltraRules struct {
+	ClassicRules
+}
+
+// Name returns "ultra".
+func (UltraRules) Name() string {
+	return "ultra"
+}
+
+// SelectionSize returns 4.
+func (UltraRules) SelectionSize() int {
+	return 4
+}
+
+// IsSet returns whether four cards form an Ultra.
+func (UltraRules) IsSet(cards []*Card) bool {
+	return (len(cards) == 4) && solver.IsUltra(cards[0].id, cards[1].id, cards[2].id, cards[3].id)
+}
+
+// ExtendSize returns 3 if no four cards on the table form an Ultra.
+func (UltraRules) ExtendSize(table []*Card) int {
+	if solver.HasUltra(TableIDs(table)) {
+		return 0
+	}
+	return extendSize
+}
+
+// TableIDs returns the ids of the cards at each table position, with -1 for
+// empty positions, as the solver expects.
+func TableIDs(table []*Card) []int {
+	ids := make([]int, len(table))
+	for i, card := range table {
+		ids[i] = -1
+		if card != nil {
+			ids[i] = card.id
+		}
+	}
+	return ids
+}
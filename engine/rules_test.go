@@ -0,0 +1,79 @@
+package engine_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// make a table of distinct random cards from the rules' deck, with some empty positions
+func randomTable(random *rand.Rand, rules engine.Rules, size int) []*engine.Card {
+	deck := rules.NewDeck()
+	table := make([]*engine.Card, size)
+	for i, id := range random.Perm(len(deck))[:size] {
+		if random.Intn(5) > 0 {
+			table[i] = &deck[id]
+		}
+	}
+	return table
+}
+
+// find whether some cards on a table form a set the slow way, by checking
+// every selection the rules allow with IsSet
+func bruteForceHasSet(rules engine.Rules, table []*engine.Card) bool {
+	var cards []*engine.Card
+	for _, card := range table {
+		if card != nil {
+			cards = append(cards, card)
+		}
+	}
+	for subset := 1; subset < (1 << len(cards)); subset++ {
+		var selection []*engine.Card
+		for i, card := range cards {
+			if subset&(1<<i) != 0 {
+				selection = append(selection, card)
+			}
+		}
+		size := rules.SelectionSize()
+		if ((size == 0) || (len(selection) == size)) && rules.IsSet(selection) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExtendSizeMatchesBruteForce(t *testing.T) {
+	names := make([]string, 0, len(engine.Variants))
+	for name := range engine.Variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rules := engine.Variants[name]
+		t.Run(name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+			// smaller tables are more likely to have no set
+			maxSize := 12
+			if rules.SelectionSize() == 0 {
+				maxSize = 8
+			}
+			extended := 0
+			for trial := 0; trial < 300; trial++ {
+				table := randomTable(random, rules, 1+random.Intn(maxSize))
+				hasSet := bruteForceHasSet(rules, table)
+				size := rules.ExtendSize(table)
+				if hasSet != (size == 0) {
+					t.Fatalf("ExtendSize(%v) = %d, but the table has a set: %v", engine.TableIDs(table), size, hasSet)
+				}
+				if size > 0 {
+					extended++
+				}
+			}
+			if (extended == 0) || (extended == 300) {
+				t.Errorf("extended %d of 300 tables, want some of each", extended)
+			}
+		})
+	}
+}
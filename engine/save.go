@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SaveVersion is the version of the save format written by Game.Save.
+const SaveVersion = 1
+
+// the serialized form of a game in progress
+type savedGame struct {
+	Version    int     `json:"version"`
+	Seed       int64   `json:"seed"`
+	Rules      string  `json:"rules,omitempty"`      // the name of the variant, or empty for the classic rules
+	Difficulty string  `json:"difficulty,omitempty"` // the difficulty level, or empty for normal
+	Draws      int     `json:"draws"`                // values drawn from the source of randomness
+	Table      []int   `json:"table"`                // card ids in each table position, or -1 if empty
+	Selected   []int   `json:"selected"`             // table positions of selected cards
+	Collected  []int   `json:"collected"`            // ids of cards collected in sets
+	SetSizes   []int   `json:"set_sizes,omitempty"`  // the number of cards in each set found, in order
+	Score      int     `json:"score"`
+	Points     *Points `json:"points,omitempty"`   // the score broken down by where the points came from
+	Scoring    string  `json:"scoring,omitempty"`  // the name of the scoring policy, or empty for classic scoring
+	LastSet    int     `json:"last_set,omitempty"` // the tick the last set was found at
+	Streak     int     `json:"streak"`
+	Elapsed    int     `json:"elapsed"` // ticks of game time
+}
+
+// Save writes the state of the game so it can be resumed with LoadGame.
+// Cards that are being animated are saved in the state they will come to rest in.
+func (g *Game) Save(w io.Writer) error {
+	s := savedGame{
+		Version:    SaveVersion,
+		Seed:       g.seed,
+		Rules:      g.rules.Name(),
+		Difficulty: g.difficulty.String(),
+		Draws:      g.draws,
+		Table:      make([]int, len(g.table)),
+		Selected:   make([]int, 0),
+		Collected:  make([]int, 0),
+		SetSizes:   g.setSizes,
+		Score:      g.Score(),
+		Points:     &g.points,
+		Scoring:    g.scoring.Name(),
+		LastSet:    g.lastSet,
+		Streak:     g.streak,
+		Elapsed:    g.elapsed,
+	}
+	for i, card := range g.table {
+		s.Table[i] = -1
+		if card != nil {
+			s.Table[i] = card.id
+			if card.selected {
+				s.Selected = append(s.Selected, i)
+			}
+		}
+	}
+	for i := range g.deck {
+		layer := g.deck[i].layer
+		if (layer == LayerCollecting) || (layer == LayerCollected) {
+			s.Collected = append(s.Collected, g.deck[i].id)
+		}
+	}
+	return json.NewEncoder(w).Encode(s)
+}
+
+// LoadGame reads a game written by Game.Save.
+func LoadGame(r io.Reader) (*Game, error) {
+	var s savedGame
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.Version != SaveVersion {
+		return nil, fmt.Errorf("unsupported save version %d", s.Version)
+	}
+	if len(s.Table) > TableSize {
+		return nil, fmt.Errorf("saved table has %d positions, more than %d", len(s.Table), TableSize)
+	}
+	rules := Rules(ClassicRules{})
+	if s.Rules != "" {
+		var ok bool
+		if rules, ok = Variants[s.Rules]; !ok {
+			return nil, fmt.Errorf("unknown rules %q", s.Rules)
+		}
+	}
+	difficulty := DifficultyNormal
+	if s.Difficulty != "" {
+		var ok bool
+		if difficulty, ok = Difficulties[s.Difficulty]; !ok {
+			return nil, fmt.Errorf("unknown difficulty %q", s.Difficulty)
+		}
+	}
+	if !difficulty.Supports(rules) {
+		return nil, fmt.Errorf("%s rules can't be played at %s difficulty", rules.Name(), difficulty)
+	}
+	g := newGame(s.Seed, rules)
+	g.difficulty = difficulty
+	for i := 0; i < s.Draws; i++ {
+		g.draw()
+	}
+	g.points = Points{Base: s.Score}
+	if s.Points != nil {
+		g.points = *s.Points
+	}
+	if s.Scoring != "" {
+		var ok bool
+		if g.scoring, ok = ScoringPolicies[s.Scoring]; !ok {
+			return nil, fmt.Errorf("unknown scoring %q", s.Scoring)
+		}
+	}
+	g.lastSet = s.LastSet
+	g.setSizes = s.SetSizes
+	if size := rules.SelectionSize(); (g.setSizes == nil) && (size > 0) {
+		// saves from before set sizes were stored only have sets of a fixed size
+		for i := 0; i < len(s.Collected)/size; i++ {
+			g.setSizes = append(g.setSizes, size)
+		}
+	}
+	g.streak = s.Streak
+	g.elapsed = s.Elapsed
+	for _, id := range s.Collected {
+		if (id < 0) || (id >= len(g.deck)) {
+			return nil, fmt.Errorf("invalid collected card %d", id)
+		}
+		g.deck[id].layer = LayerCollected
+	}
+	for i, id := range s.Table {
+		if id < 0 {
+			continue
+		}
+		if (id >= len(g.deck)) || (g.deck[id].layer != LayerNotDealt) {
+			return nil, fmt.Errorf("invalid card %d at table position %d", id, i)
+		}
+		card := &g.deck[id]
+		card.col, card.row = TableCoords(i)
+		card.turn = FrontTurn
+		card.layer = LayerDealt
+		g.table[i] = card
+	}
+	for _, i := range s.Selected {
+		if (i >= 0) && (i < len(g.table)) && (g.table[i] != nil) {
+			g.table[i].selected = true
+		}
+	}
+	// deal anything that was pending and check whether the table needs to be extended
+	g.tidyTable()
+	return g, nil
+}
@@ -0,0 +1,150 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+// select the first set on the table, returning false if there is none
+func selectFirstSet(g *engine.Game) bool {
+	sets := solver.Sets(engine.TableIDs(g.Table()))
+	if len(sets) == 0 {
+		return false
+	}
+	for _, slot := range sets[0] {
+		g.Input(rune('a' + slot))
+	}
+	return true
+}
+
+// save a game to a string
+func saved(t *testing.T, g *engine.Game) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := g.Save(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+// a game to save, which finds some sets after dealing
+type saveCase struct {
+	name string
+	game func() *engine.Game
+	sets int
+}
+
+var saveCases = []saveCase{
+	{"new", func() *engine.Game { return engine.NewGame(1) }, 0},
+	{"sets found", func() *engine.Game { return engine.NewGame(1) }, 3},
+	{"junior", func() *engine.Game { return engine.NewGameWithRules(1, engine.JuniorRules) }, 2},
+	{"hard", func() *engine.Game {
+		return engine.NewGameWithDifficulty(1, engine.ClassicRules{}, engine.DifficultyHard)
+	}, 2},
+	{"streak", func() *engine.Game {
+		g := engine.NewGame(1)
+		g.SetScoring(engine.StreakScoring{})
+		return g
+	}, 3},
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	for _, c := range saveCases {
+		t.Run(c.name, func(t *testing.T) {
+			g := c.game()
+			for i := 0; i < 200; i++ {
+				g.Step()
+			}
+			for i := 0; i < c.sets; i++ {
+				if !selectFirstSet(g) {
+					t.Fatalf("no set on the table after %d sets", i)
+				}
+				for j := 0; j < 200; j++ {
+					g.Step()
+				}
+			}
+			g.Input('a')
+			before := saved(t, g)
+			loaded, err := engine.LoadGame(strings.NewReader(before))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if after := saved(t, loaded); after != before {
+				t.Errorf("saved again as\n%s\nwant\n%s", after, before)
+			}
+			if (loaded.Score() != g.Score()) || (loaded.SetsCollected() != c.sets) ||
+				(loaded.Elapsed() != g.Elapsed()) || (loaded.Rules().Name() != g.Rules().Name()) ||
+				(loaded.Difficulty() != g.Difficulty()) || (loaded.Points() != g.Points()) ||
+				(loaded.Scoring().Name() != g.Scoring().Name()) {
+				t.Errorf("loaded score %d, sets %d, time %v, rules %s, difficulty %s, points %+v, scoring %s; "+
+					"want %d, %d, %v, %s, %s, %+v, %s",
+					loaded.Score(), loaded.SetsCollected(), loaded.Elapsed(), loaded.Rules().Name(),
+					loaded.Difficulty(), loaded.Points(), loaded.Scoring().Name(),
+					g.Score(), c.sets, g.Elapsed(), g.Rules().Name(), g.Difficulty(), g.Points(), g.Scoring().Name())
+			}
+		})
+	}
+}
+
+// change a field of a saved game
+func withField(t *testing.T, save string, key string, value interface{}) string {
+	t.Helper()
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(save), &fields); err != nil {
+		t.Fatal(err)
+	}
+	fields[key] = value
+	changed, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(changed)
+}
+
+func TestLoadGameErrors(t *testing.T) {
+	g := engine.NewGame(1)
+	for i := 0; i < 200; i++ {
+		g.Step()
+	}
+	valid := saved(t, g)
+	table := g.Table()
+	ids := make([]int, len(table))
+	for i, card := range table {
+		ids[i] = -1
+		if card != nil {
+			ids[i] = card.ID()
+		}
+	}
+	sameCards := append([]int(nil), ids...)
+	sameCards[1] = sameCards[0]
+	invalidCard := append([]int(nil), ids...)
+	invalidCard[0] = len(g.Cards())
+	for _, c := range []struct {
+		name string
+		save string
+	}{
+		{"empty", ""},
+		{"truncated", valid[:len(valid)/2]},
+		{"not json", "version 1\n"},
+		{"bad version", withField(t, valid, "version", 99)},
+		{"unknown rules", withField(t, valid, "rules", "nope")},
+		{"unknown difficulty", withField(t, valid, "difficulty", "nope")},
+		{"unsupported difficulty", withField(t, saved(t, engine.NewGameWithRules(1, engine.ProSetRules{})), "difficulty", "hard")},
+		{"unknown scoring", withField(t, valid, "scoring", "nope")},
+		{"invalid card", withField(t, valid, "table", invalidCard)},
+		{"card on the table twice", withField(t, valid, "table", sameCards)},
+		{"too many table positions", withField(t, valid, "table", append(ids, -1))},
+		{"invalid collected card", withField(t, valid, "collected", []int{-1})},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := engine.LoadGame(strings.NewReader(c.save)); err == nil {
+				t.Error("loaded without an error")
+			}
+		})
+	}
+}
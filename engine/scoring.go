@@ -0,0 +1,163 @@
+package engine
+
+import "time"
+
+// Points are a change in score, broken down by where they came from.
+type Points struct {
+	Base    int `json:"base"`    // a point for each set found
+	Bonus   int `json:"bonus"`   // extra points the rules give for harder sets
+	Streak  int `json:"streak"`  // extra points for finding sets in a row
+	Speed   int `json:"speed"`   // extra points for finding a set quickly
+	Penalty int `json:"penalty"` // points taken away for mistakes, as a negative number
+}
+
+// Total returns the sum of all points.
+func (p Points) Total() int {
+	return p.Base + p.Bonus + p.Streak + p.Speed + p.Penalty
+}
+
+// Add another change in score to the points.
+func (p *Points) Add(other Points) {
+	p.Base += other.Base
+	p.Bonus += other.Bonus
+	p.Streak += other.Streak
+	p.Speed += other.Speed
+	p.Penalty += other.Penalty
+}
+
+// A Selection describes cards the player selected, for scoring.
+type Selection struct {
+	Cards  []*Card       // the cards selected
+	IsSet  bool          // whether the cards form a set, or whether a trainer answer about them was right
+	Points Points        // the points the rules give for the selection
+	Streak int           // the number of sets found in a row before this selection
+	Since  time.Duration // the game time since the last set was found, or since the game started
+}
+
+// A ScoringPolicy decides how selections change the score.
+type ScoringPolicy interface {
+	// Name returns the name of the policy, as used in ScoringPolicies.
+	Name() string
+	// Score returns the points for a selection.
+	Score(s Selection) Points
+}
+
+// ScoringPolicies holds the built-in scoring policies by name.
+var ScoringPolicies = map[string]ScoringPolicy{
+	"classic":  ClassicScoring{},
+	"streak":   StreakScoring{},
+	"speed":    SpeedScoring{},
+	"practice": PracticeScoring{},
+}
+
+// ClassicScoring gives the points the rules give for each selection.
+type ClassicScoring struct{}
+
+// Name returns "classic".
+func (ClassicScoring) Name() string {
+	return "classic"
+}
+
+// Score gives the points the rules give.
+func (ClassicScoring) Score(s Selection) Points {
+	return s.Points
+}
+
+// StreakScoring multiplies the points for a set by the number of sets found
+// in a row, up to a limit.
+type StreakScoring struct{}
+
+// the highest multiple of points for sets found in a row
+const maxStreakMultiplier = 4
+
+// Name returns "streak".
+func (StreakScoring) Name() string {
+	return "streak"
+}
+
+// Score gives classic points, with the points for a set multiplied by its place in a streak.
+func (StreakScoring) Score(s Selection) Points {
+	points := ClassicScoring{}.Score(s)
+	if s.IsSet {
+		points.Streak = (points.Base + points.Bonus) * min(s.Streak, maxStreakMultiplier-1)
+	}
+	return points
+}
+
+// SpeedScoring gives extra points for finding a set soon after the last one.
+type SpeedScoring struct{}
+
+// the time it takes for the speed bonus to drop by a point
+const speedBonusInterval = 10 * time.Second
+
+// the speed bonus for a set found right after the last one
+const maxSpeedBonus = 3
+
+// Name returns "speed".
+func (SpeedScoring) Name() string {
+	return "speed"
+}
+
+// Score gives classic points, with a bonus for a set that shrinks the longer it took to find.
+func (SpeedScoring) Score(s Selection) Points {
+	points := ClassicScoring{}.Score(s)
+	if s.IsSet {
+		points.Speed = max(0, maxSpeedBonus-int(s.Since/speedBonusInterval))
+	}
+	return points
+}
+
+// PracticeScoring gives classic points for sets and never takes points away.
+type PracticeScoring struct{}
+
+// Name returns "practice".
+func (PracticeScoring) Name() string {
+	return "practice"
+}
+
+// Score gives classic points for a set and nothing for a mistake.
+func (PracticeScoring) Score(s Selection) Points {
+	if !s.IsSet {
+		return Points{}
+	}
+	return ClassicScoring{}.Score(s)
+}
+
+// SetScoring changes how selections are scored from now on.
+func (g *Game) SetScoring(policy ScoringPolicy) {
+	g.scoring = policy
+}
+
+// Scoring returns how selections are scored.
+func (g *Game) Scoring() ScoringPolicy {
+	return g.scoring
+}
+
+// Points returns the player's score broken down by where the points came from.
+func (g *Game) Points() Points {
+	return g.points
+}
+
+// IMPLEMENTATION *************************************************************
+
+// score selected cards with the scoring policy and return the change in score
+func (g *Game) scoreSelection(cards []*Card, isSet bool) int {
+	return g.scoreAnswer(cards, isSet, g.rules.Score(cards, isSet))
+}
+
+// score an answer about cards with the scoring policy, given the points the
+// rules give for it, and return the change in score
+func (g *Game) scoreAnswer(cards []*Card, right bool, rulePoints Points) int {
+	points := g.scoring.Score(Selection{
+		Cards:  cards,
+		IsSet:  right,
+		Points: rulePoints,
+		Streak: g.streak,
+		Since:  time.Duration(g.elapsed-g.lastSet) * TickDuration,
+	})
+	if right {
+		g.lastSet = g.elapsed
+	}
+	g.points.Add(points)
+	return points.Total()
+}
@@ -0,0 +1,159 @@
+package engine_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+func TestScoringPolicies(t *testing.T) {
+	set := engine.Selection{IsSet: true, Points: engine.Points{Base: 1, Bonus: 1}, Streak: 5, Since: 5 * time.Second}
+	slowSet := engine.Selection{IsSet: true, Points: engine.Points{Base: 1}, Streak: 1, Since: 25 * time.Second}
+	mistake := engine.Selection{IsSet: false, Points: engine.Points{Penalty: -1}, Streak: 0, Since: time.Second}
+	for _, c := range []struct {
+		policy    string
+		selection engine.Selection
+		points    engine.Points
+	}{
+		{"classic", set, engine.Points{Base: 1, Bonus: 1}},
+		{"classic", slowSet, engine.Points{Base: 1}},
+		{"classic", mistake, engine.Points{Penalty: -1}},
+		{"streak", set, engine.Points{Base: 1, Bonus: 1, Streak: 6}},
+		{"streak", slowSet, engine.Points{Base: 1, Streak: 1}},
+		{"streak", mistake, engine.Points{Penalty: -1}},
+		{"speed", set, engine.Points{Base: 1, Bonus: 1, Speed: 3}},
+		{"speed", slowSet, engine.Points{Base: 1, Speed: 1}},
+		{"speed", mistake, engine.Points{Penalty: -1}},
+		{"practice", set, engine.Points{Base: 1, Bonus: 1}},
+		{"practice", slowSet, engine.Points{Base: 1}},
+		{"practice", mistake, engine.Points{}},
+	} {
+		policy := engine.ScoringPolicies[c.policy]
+		if policy.Name() != c.policy {
+			t.Errorf("policy %s is named %s", c.policy, policy.Name())
+		}
+		if points := policy.Score(c.selection); points != c.points {
+			t.Errorf("%s scored %+v as %+v, want %+v", c.policy, c.selection, points, c.points)
+		}
+	}
+}
+
+// find the first set and the first cards that aren't one in the rules' deck
+func firstSetAndMistake(rules engine.Rules) (set []*engine.Card, mistake []*engine.Card) {
+	deck := rules.NewDeck()
+	size := rules.SelectionSize()
+	if size == 0 {
+		size = 3
+	}
+	// step through selections of cards in order, like an odometer
+	indices := make([]int, size)
+	for i := range indices {
+		indices[i] = i
+	}
+	for (set == nil) || (mistake == nil) {
+		cards := make([]*engine.Card, size)
+		for i, index := range indices {
+			cards[i] = &deck[index]
+		}
+		if !rules.IsSet(cards) {
+			if mistake == nil {
+				mistake = cards
+			}
+		} else if set == nil {
+			set = cards
+		}
+		i := size - 1
+		for (i > 0) && (indices[i] == len(deck)-size+i) {
+			i--
+		}
+		indices[i]++
+		for j := i + 1; j < size; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+	return set, mistake
+}
+
+func TestScoringAgreesWithRules(t *testing.T) {
+	for name, rules := range engine.Variants {
+		set, mistake := firstSetAndMistake(rules)
+		selections := []engine.Selection{
+			{Cards: set, IsSet: true, Points: rules.Score(set, true), Streak: 2, Since: time.Second},
+		}
+		// cards are only claimed once they form a set when sets can have any number of them
+		if rules.SelectionSize() > 0 {
+			selections = append(selections,
+				engine.Selection{Cards: mistake, IsSet: false, Points: rules.Score(mistake, false), Streak: 2, Since: time.Second})
+		}
+		for _, selection := range selections {
+			if selection.IsSet && ((selection.Points.Base != 1) || (selection.Points.Penalty != 0)) {
+				t.Errorf("%s rules score a set as %+v, want a base point", name, selection.Points)
+			}
+			if !selection.IsSet && ((selection.Points.Base != 0) || (selection.Points.Bonus != 0)) {
+				t.Errorf("%s rules score a mistake as %+v, want no base or bonus", name, selection.Points)
+			}
+			for policyName, policy := range engine.ScoringPolicies {
+				points := policy.Score(selection)
+				// policies add their own points to what the rules give, and only practice leaves out penalties
+				ruled := points
+				ruled.Streak, ruled.Speed = 0, 0
+				want := selection.Points
+				if policyName == "practice" {
+					want.Penalty = 0
+				}
+				if ruled != want {
+					t.Errorf("%s policy scored %+v by %s rules as %+v, want %+v plus its own points",
+						policyName, selection.Points, name, points, want)
+				}
+			}
+		}
+	}
+}
+
+func TestPointsTotal(t *testing.T) {
+	p := engine.Points{Base: 3, Bonus: 2}
+	p.Add(engine.Points{Base: 1, Streak: 4, Speed: 2, Penalty: -3})
+	if want := (engine.Points{Base: 4, Bonus: 2, Streak: 4, Speed: 2, Penalty: -3}); p != want {
+		t.Errorf("added up to %+v, want %+v", p, want)
+	}
+	if p.Total() != 9 {
+		t.Errorf("total is %d, want 9", p.Total())
+	}
+}
+
+// answer the trainer's question rightly or wrongly and return the change in score
+func answerTrainer(g *engine.Game, right bool) int {
+	shown := trainerCards(g)
+	isSet := engine.AreSet(shown[0], shown[1], shown[2])
+	answer := engine.TrainerNo
+	if isSet == right {
+		answer = engine.TrainerYes
+	}
+	before := g.Score()
+	g.Input(answer)
+	return g.Score() - before
+}
+
+func TestTrainerScoring(t *testing.T) {
+	for _, c := range []struct {
+		policy  string
+		answers []bool
+		changes []int
+	}{
+		{"classic", []bool{true, true, true, false}, []int{1, 1, 1, -1}},
+		{"streak", []bool{true, true, true, true, false, true}, []int{1, 2, 3, 4, -1, 1}},
+		{"practice", []bool{true, false, true}, []int{1, 0, 1}},
+	} {
+		g := engine.NewTrainer(1, nil)
+		g.SetScoring(engine.ScoringPolicies[c.policy])
+		for i, right := range c.answers {
+			if change := answerTrainer(g, right); change != c.changes[i] {
+				t.Errorf("%s answer %d changed the score by %d, want %d", c.policy, i, change, c.changes[i])
+			}
+		}
+		if g.Points().Total() != g.Score() {
+			t.Errorf("%s points total %d, but the score is %d", c.policy, g.Points().Total(), g.Score())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package engine
+
+// AreSet returns whether three cards form a set.
+func AreSet(a, b, c *Card) bool {
+	a1, a2, a3, a4 := a.Attributes()
+	b1, b2, b3, b4 := b.Attributes()
+	c1, c2, c3, c4 := c.Attributes()
+	return (true &&
+		areSameOrDifferent(a1, b1, c1) &&
+		areSameOrDifferent(a2, b2, c2) &&
+		areSameOrDifferent(a3, b3, c3) &&
+		areSameOrDifferent(a4, b4, c4))
+}
+func areSameOrDifferent(a, b, c int) bool {
+	return areSame(a, b, c) || areDifferent(a, b, c)
+}
+func areSame(a, b, c int) bool {
+	return (a == b) && (b == c)
+}
+func areDifferent(a, b, c int) bool {
+	return (a != b) && (b != c) && (a != c)
+}
@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+
+	"github.com/jessecrossen/go81/solver"
+)
+
+// TrainerYes and TrainerNo are the inputs that answer whether the cards shown by the trainer form a set.
+const (
+	TrainerYes = 'y'
+	TrainerNo  = 'n'
+)
+
+// the chance out of 100 that the trainer shows a set
+const trainerSetChance = 40
+
+// an "is this a set?" trainer
+type trainer struct {
+	g        *Game                               // the game the trainer is played in
+	cards    [3]*Card                            // the cards shown
+	isSet    bool                                // whether the cards shown form a set
+	asked    int                                 // the tick the cards were shown at
+	answered bool                                // whether any question has been answered
+	right    bool                                // whether the last answer was right
+	failing  []int                               // the attributes that kept the last cards shown from being a set
+	stats    [len(AttributeNames)]AttributeStats // answers about sets the attribute differed in, or cards it alone kept from being one
+}
+
+// NewTrainer returns a game that shows three cards at a time and asks whether
+// they form a set, continuing from the stats of earlier sessions, if any.
+// Cards that aren't a set are more likely to fail on attributes the player
+// has gotten wrong more often.
+func NewTrainer(seed int64, stats []AttributeStats) *Game {
+	g := newGame(seed, ClassicRules{})
+	t := &trainer{g: g}
+	copy(t.stats[:], stats)
+	g.mode = t
+	t.ask()
+	return g
+}
+
+// TrainerStats returns answers for each attribute in AttributeNames, counting
+// sets the attribute differed in and cards that only that attribute kept from
+// being a set, or nil if the game isn't a trainer.
+func (g *Game) TrainerStats() []AttributeStats {
+	t, ok := g.mode.(*trainer)
+	if !ok {
+		return nil
+	}
+	return t.stats[:]
+}
+
+// TrainerFeedback returns whether the last answer to the trainer was right,
+// and the attributes that kept the cards from being a set. It returns false
+// for answered if the game isn't a trainer or nothing has been answered.
+func (g *Game) TrainerFeedback() (answered bool, right bool, failing []int) {
+	t, ok := g.mode.(*trainer)
+	if !ok {
+		return false, false, nil
+	}
+	return t.answered, t.right, t.failing
+}
+
+// IMPLEMENTATION *************************************************************
+
+// only answers to the question are taken
+func (t *trainer) checkInput(c rune) error {
+	switch {
+	case (unicode.ToLower(c) != TrainerYes) && (unicode.ToLower(c) != TrainerNo):
+		return fmt.Errorf("unrecognized input %q", c)
+	case t.g.paused:
+		return fmt.Errorf("the game is paused")
+	}
+	return nil
+}
+
+// answering shows the next cards
+func (t *trainer) input(c rune) {
+	t.checkAnswer(c)
+}
+
+// the cards in question are shown off the table
+func (t *trainer) shown() []*Card {
+	return t.cards[:]
+}
+
+// show three cards that may or may not form a set
+func (t *trainer) ask() {
+	g := t.g
+	for _, card := range t.cards {
+		if card != nil {
+			card.layer = LayerNotDealt
+		}
+	}
+	t.isSet = g.draw()%100 < trainerSetChance
+	t.cards = t.pickCards(t.isSet)
+	for i, card := range t.cards {
+		card.col, card.row = TableCoords(i * 3)
+		card.shrink = 0
+		card.selected = false
+		card.turn = BackTurn
+		card.layer = LayerDealt
+		g.animator.Animate(*revealAnimation(card))
+	}
+	t.asked = g.elapsed
+	g.needsRender = true
+}
+
+// pick a set, or cards that fail to be a set on one attribute chosen by how often the player gets it wrong
+func (t *trainer) pickCards(isSet bool) [3]*Card {
+	g := t.g
+	attribute := t.pickWeakAttribute()
+	for {
+		a, b := g.pickCard(), g.pickCard()
+		if a == b {
+			continue
+		}
+		third := solver.Third(a.id, b.id)
+		if !isSet {
+			// change the attribute to a value that breaks the set
+			place := 1
+			for i := 0; i < attribute; i++ {
+				place *= ClassicDeck.Values
+			}
+			value := ClassicDeck.Attribute(third, attribute)
+			other := (value + 1 + int(g.draw()%2)) % ClassicDeck.Values
+			third += (other - value) * place
+		}
+		if (third == a.id) || (third == b.id) {
+			continue
+		}
+		// show the cards in a random order
+		cards := [3]*Card{a, b, &g.deck[third]}
+		for i := len(cards) - 1; i > 0; i-- {
+			j := int(g.draw() % int64(i+1))
+			cards[i], cards[j] = cards[j], cards[i]
+		}
+		return cards
+	}
+}
+
+// pick an attribute with a chance proportional to how often the player gets it wrong,
+// starting from an even chance for attributes with few answers
+func (t *trainer) pickWeakAttribute() int {
+	const scale = 1000
+	weights := make([]int, len(t.stats))
+	total := 0
+	for i, stats := range t.stats {
+		weights[i] = scale * (stats.Asked - stats.Correct + 1) / (stats.Asked + 2)
+		total += weights[i]
+	}
+	pick := int(t.g.draw() % int64(total))
+	for i, weight := range weights {
+		if pick < weight {
+			return i
+		}
+		pick -= weight
+	}
+	return len(weights) - 1
+}
+
+// check an answer to the trainer and show the next cards
+func (t *trainer) checkAnswer(c rune) {
+	g := t.g
+	saidSet := unicode.ToLower(c) == TrainerYes
+	isSet := AreSet(t.cards[0], t.cards[1], t.cards[2])
+	t.answered = true
+	t.right = saidSet == isSet
+	t.failing = failingAttributes(t.cards[0], t.cards[1], t.cards[2])
+	// count the answer toward the attributes that differ in a set, which are
+	// the easiest to misjudge, or toward the one attribute that broke a set
+	var counted []int
+	if isSet {
+		counted = differingAttributes(t.cards[0], t.cards[1])
+	} else if len(t.failing) == 1 {
+		counted = t.failing
+	}
+	for _, attribute := range counted {
+		stats := &t.stats[attribute]
+		stats.Asked++
+		if t.right {
+			stats.Correct++
+		}
+		stats.Time += time.Duration(g.elapsed-t.asked) * TickDuration
+	}
+	// answers are worth a point whether or not the cards form a set
+	points := Points{Penalty: -1}
+	if t.right {
+		points = Points{Base: 1}
+	}
+	change := g.scoreAnswer(t.cards[:], t.right, points)
+	centerCol, centerRow := cardsCenter(t.cards[:])
+	g.effects.Add(&g.animator, floatEffect(change, centerCol, centerRow))
+	if t.right {
+		g.streak++
+	} else {
+		g.mistakes++
+		g.streak = 0
+	}
+	t.ask()
+}
+
+// get the attributes that differ between two classic cards
+func differingAttributes(a, b *Card) []int {
+	differing := make([]int, 0)
+	for attribute := range AttributeNames {
+		if ClassicDeck.Attribute(a.id, attribute) != ClassicDeck.Attribute(b.id, attribute) {
+			differing = append(differing, attribute)
+		}
+	}
+	return differing
+}
+
+// get the attributes that keep three classic cards from being a set
+func failingAttributes(a, b, c *Card) []int {
+	failing := make([]int, 0)
+	for attribute := range AttributeNames {
+		if !areSameOrDifferent(ClassicDeck.Attribute(a.id, attribute),
+			ClassicDeck.Attribute(b.id, attribute), ClassicDeck.Attribute(c.id, attribute)) {
+			failing = append(failing, attribute)
+		}
+	}
+	return failing
+}
@@ -0,0 +1,78 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// get the three cards the trainer is asking about
+func trainerCards(g *engine.Game) []*engine.Card {
+	shown := make([]*engine.Card, 0, 3)
+	cards := g.Cards()
+	for i := range cards {
+		if cards[i].Layer() == engine.LayerDealt {
+			shown = append(shown, &cards[i])
+		}
+	}
+	return shown
+}
+
+func TestTrainerFavorsWeakAttribute(t *testing.T) {
+	const color = 3
+	stats := make([]engine.AttributeStats, len(engine.AttributeNames))
+	for i := range stats {
+		stats[i] = engine.AttributeStats{Asked: 1000, Correct: 1000}
+	}
+	stats[color].Correct = 500
+	g := engine.NewTrainer(1, stats)
+	failures := make([]int, len(engine.AttributeNames))
+	total := 0
+	for question := 0; question < 200; question++ {
+		answerTrainer(g, true)
+		if _, _, failing := g.TrainerFeedback(); len(failing) == 1 {
+			failures[failing[0]]++
+			total++
+		}
+	}
+	if failures[color] < total*3/4 {
+		t.Errorf("%d of %d cards that weren't a set failed on color, want most of them", failures[color], total)
+	}
+}
+
+func TestTrainerStatsCountEveryAnswer(t *testing.T) {
+	g := engine.NewTrainer(1, nil)
+	expected := make([]engine.AttributeStats, len(engine.AttributeNames))
+	sets := 0
+	for question := 0; question < 100; question++ {
+		cards := trainerCards(g)
+		right := question%3 != 0
+		isSet := engine.AreSet(cards[0], cards[1], cards[2])
+		for attribute := range expected {
+			values := make(map[int]bool)
+			for _, card := range cards {
+				values[engine.ClassicDeck.Attribute(card.ID(), attribute)] = true
+			}
+			// sets count toward attributes that differ, and other cards toward the one that broke the set
+			if (isSet && (len(values) == 3)) || (!isSet && (len(values) == 2)) {
+				expected[attribute].Asked++
+				if right {
+					expected[attribute].Correct++
+				}
+			}
+		}
+		if isSet {
+			sets++
+		}
+		answerTrainer(g, right)
+	}
+	if sets == 0 {
+		t.Fatal("the trainer showed no sets")
+	}
+	for i, stats := range g.TrainerStats() {
+		if (stats.Asked != expected[i].Asked) || (stats.Correct != expected[i].Correct) {
+			t.Errorf("%s stats have %d of %d right, want %d of %d", engine.AttributeNames[i],
+				stats.Correct, stats.Asked, expected[i].Correct, expected[i].Asked)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/jessecrossen/go81
+
+go 1.16
@@ -0,0 +1,183 @@
+package raster
+
+import "math"
+
+// a glyph bitmap with one byte per row and the leftmost pixel in bit 7
+type glyph = [CellHeight]uint8
+
+// the size of the ASCII glyphs and where they sit in a cell
+const (
+	asciiWidth   = 6
+	asciiHeight  = 13
+	asciiOffsetX = 1
+	asciiOffsetY = 1
+)
+
+// the pixel lines box-drawing glyphs meet at
+const (
+	centerX = 3
+	centerY = 7
+)
+
+// glyphs that are drawn procedurally, covering everything the game draws beyond ASCII
+var shapeGlyphs = map[rune]func(*glyph){
+	'─': func(g *glyph) { hline(g, 0, CellWidth-1, centerY) },
+	'│': func(g *glyph) { vline(g, centerX, 0, CellHeight-1) },
+	'╭': func(g *glyph) {
+		hline(g, centerX+2, CellWidth-1, centerY)
+		set(g, centerX+1, centerY+1)
+		vline(g, centerX, centerY+2, CellHeight-1)
+	},
+	'╮': func(g *glyph) {
+		hline(g, 0, centerX-2, centerY)
+		set(g, centerX-1, centerY+1)
+		vline(g, centerX, centerY+2, CellHeight-1)
+	},
+	'╰': func(g *glyph) {
+		vline(g, centerX, 0, centerY-2)
+		set(g, centerX+1, centerY-1)
+		hline(g, centerX+2, CellWidth-1, centerY)
+	},
+	'╯': func(g *glyph) {
+		vline(g, centerX, 0, centerY-2)
+		set(g, centerX-1, centerY-1)
+		hline(g, 0, centerX-2, centerY)
+	},
+	'┌': func(g *glyph) {
+		hline(g, centerX, CellWidth-1, centerY)
+		vline(g, centerX, centerY, CellHeight-1)
+	},
+	'┐': func(g *glyph) {
+		hline(g, 0, centerX, centerY)
+		vline(g, centerX, centerY, CellHeight-1)
+	},
+	'└': func(g *glyph) {
+		vline(g, centerX, 0, centerY)
+		hline(g, centerX, CellWidth-1, centerY)
+	},
+	'┘': func(g *glyph) {
+		vline(g, centerX, 0, centerY)
+		hline(g, 0, centerX, centerY)
+	},
+	'╷': func(g *glyph) { vline(g, centerX, centerY, CellHeight-1) },
+	'╵': func(g *glyph) { vline(g, centerX, 0, centerY) },
+	'▯': func(g *glyph) {
+		hline(g, 1, 5, 3)
+		hline(g, 1, 5, 12)
+		vline(g, 1, 3, 12)
+		vline(g, 5, 3, 12)
+	},
+	'·': func(g *glyph) { set(g, centerX, centerY) },
+	'△': func(g *glyph) { shape(g, inTriangle, false, false) },
+	'◮': func(g *glyph) { shape(g, inTriangle, false, true) },
+	'▲': func(g *glyph) { shape(g, inTriangle, true, false) },
+	'□': func(g *glyph) { shape(g, inSquare, false, false) },
+	'◨': func(g *glyph) { shape(g, inSquare, false, true) },
+	'■': func(g *glyph) { shape(g, inSquare, true, false) },
+	'○': func(g *glyph) { shape(g, inCircle, false, false) },
+	'◑': func(g *glyph) { shape(g, inCircle, false, true) },
+	'●': func(g *glyph) { shape(g, inCircle, true, false) },
+	'✧': func(g *glyph) { shape(g, inStar, false, false) },
+	'✦': func(g *glyph) { shape(g, inStar, true, false) },
+}
+
+// all glyphs, built once so frames can be drawn from any goroutine
+var glyphs = buildGlyphs()
+
+// get the bitmap for a character, falling back to a question mark
+func glyphFor(r rune) *glyph {
+	if g, ok := glyphs[r]; ok {
+		return g
+	}
+	return glyphs['?']
+}
+
+// build bitmaps for every character the font covers
+func buildGlyphs() map[rune]*glyph {
+	built := make(map[rune]*glyph, len(asciiGlyphs)+len(shapeGlyphs))
+	for i, rows := range asciiGlyphs {
+		var g glyph
+		for y, row := range rows {
+			g[y+asciiOffsetY] = row << (8 - asciiWidth - asciiOffsetX)
+		}
+		built[rune(' '+i)] = &g
+	}
+	for r, draw := range shapeGlyphs {
+		var g glyph
+		draw(&g)
+		built[r] = &g
+	}
+	return built
+}
+
+// DRAWING *******************************************************************
+
+func set(g *glyph, x, y int) {
+	if (x >= 0) && (x < CellWidth) && (y >= 0) && (y < CellHeight) {
+		g[y] |= 0x80 >> uint(x)
+	}
+}
+
+func hline(g *glyph, x0, x1, y int) {
+	for x := x0; x <= x1; x++ {
+		set(g, x, y)
+	}
+}
+
+func vline(g *glyph, x, y0, y1 int) {
+	for y := y0; y <= y1; y++ {
+		set(g, x, y)
+	}
+}
+
+// the region card symbols are drawn in, centered on the box-drawing lines
+const (
+	shapeLeft   = centerX - 3
+	shapeRight  = centerX + 3
+	shapeTop    = centerY - 3
+	shapeBottom = centerY + 3
+)
+
+// draw a symbol as an outline, filled, or with its right half filled
+func shape(g *glyph, inside func(x, y int) bool, filled bool, halfFilled bool) {
+	for y := shapeTop; y <= shapeBottom; y++ {
+		for x := shapeLeft; x <= shapeRight; x++ {
+			if !inside(x, y) {
+				continue
+			}
+			edge := !inside(x-1, y) || !inside(x+1, y) || !inside(x, y-1) || !inside(x, y+1)
+			if filled || edge || (halfFilled && (x >= centerX)) {
+				set(g, x, y)
+			}
+		}
+	}
+}
+
+func inSquare(x, y int) bool {
+	return (x >= shapeLeft) && (x <= shapeRight) && (y >= shapeTop) && (y <= shapeBottom)
+}
+
+func inTriangle(x, y int) bool {
+	if !inSquare(x, y) {
+		return false
+	}
+	halfWidth := (y - shapeTop) / 2
+	return abs(x-centerX) <= halfWidth
+}
+
+func inCircle(x, y int) bool {
+	dx, dy := float64(x-centerX), float64(y-centerY)
+	return (dx*dx)+(dy*dy) <= 3.3*3.3
+}
+
+func inStar(x, y int) bool {
+	dx, dy := math.Abs(float64(x-centerX)), float64(abs(y-centerY))
+	return math.Sqrt(dx)+math.Sqrt(dy) <= math.Sqrt(3.5)
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
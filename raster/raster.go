@@ -0,0 +1,135 @@
+// Package raster draws frames as pixel images with a built-in bitmap font, so
+// games can be shared as GIF animations or PNG snapshots without a terminal.
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"time"
+
+	"github.com/jessecrossen/go81/render"
+)
+
+// CellWidth is the width of a character cell in pixels.
+const CellWidth = 8
+
+// CellHeight is the height of a character cell in pixels.
+const CellHeight = 16
+
+// Palette holds the 16 console colors, in the order of render.ConsoleColors.
+var Palette = buildPalette()
+
+// the palette indices used for the default foreground and background
+var (
+	defaultForeground = paletteIndex(render.DefaultForeground, 0)
+	defaultBackground = paletteIndex(render.DefaultBackground, 0)
+)
+
+// Frame draws a frame into an image with the given number of character columns and rows.
+func Frame(f *render.Frame, cols int, rows int) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, cols*CellWidth, rows*CellHeight), Palette)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			r, fg, bg := f.Cell(col, row)
+			drawCell(img, col, row, glyphFor(r),
+				paletteIndex(fg, defaultForeground), paletteIndex(bg, defaultBackground))
+		}
+	}
+	return img
+}
+
+// WritePNG writes a frame as a PNG image just large enough to hold it.
+func WritePNG(w io.Writer, f *render.Frame) error {
+	cols, rows := f.Size()
+	return png.Encode(w, Frame(f, cols, rows))
+}
+
+// the minimum time a frame of a GIF can be shown for, since many viewers slow down shorter frames
+const minGIFDelay = 20 * time.Millisecond
+
+// how long to show the last frame of a GIF
+const finalGIFDelay = 2 * time.Second
+
+// An Animation collects frames and the times they appear to write as an animated GIF.
+type Animation struct {
+	frames []render.Frame
+	times  []time.Duration
+}
+
+// Add a frame that appears at the given time since the start of the animation.
+func (a *Animation) Add(f render.Frame, at time.Duration) {
+	a.frames = append(a.frames, f)
+	a.times = append(a.times, at)
+}
+
+// Len returns the number of frames in the animation.
+func (a *Animation) Len() int {
+	return len(a.frames)
+}
+
+// WriteGIF writes the animation as a GIF sized to fit its largest frame.
+func (a *Animation) WriteGIF(w io.Writer) error {
+	cols, rows := 0, 0
+	for i := range a.frames {
+		c, r := a.frames[i].Size()
+		cols, rows = max(cols, c), max(rows, r)
+	}
+	anim := gif.GIF{}
+	carried := time.Duration(0)
+	for i := range a.frames {
+		delay := finalGIFDelay
+		if i+1 < len(a.frames) {
+			delay = a.times[i+1] - a.times[i]
+		}
+		// merge frames too short to show into the frame after them
+		delay += carried
+		if (delay < minGIFDelay) && (i+1 < len(a.frames)) {
+			carried = delay
+			continue
+		}
+		carried = 0
+		anim.Image = append(anim.Image, Frame(&a.frames[i], cols, rows))
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+	}
+	return gif.EncodeAll(w, &anim)
+}
+
+// IMPLEMENTATION *************************************************************
+
+// make a palette of the console colors
+func buildPalette() color.Palette {
+	palette := make(color.Palette, len(render.ConsoleColors))
+	for i, c := range render.ConsoleColors {
+		r, g, b := render.RGB(c, c)
+		palette[i] = color.RGBA{r, g, b, 0xff}
+	}
+	return palette
+}
+
+// get the palette index for a console color
+func paletteIndex(c render.Color, defaultIndex uint8) uint8 {
+	for i, consoleColor := range render.ConsoleColors {
+		if c == consoleColor {
+			return uint8(i)
+		}
+	}
+	return defaultIndex
+}
+
+// fill a character cell with its background and draw the glyph over it
+func drawCell(img *image.Paletted, col int, row int, g *glyph, fg uint8, bg uint8) {
+	x0, y0 := col*CellWidth, row*CellHeight
+	for y := 0; y < CellHeight; y++ {
+		offset := img.PixOffset(x0, y0+y)
+		for x := 0; x < CellWidth; x++ {
+			if g[y]&(0x80>>uint(x)) != 0 {
+				img.Pix[offset+x] = fg
+			} else {
+				img.Pix[offset+x] = bg
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package raster_test
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/raster"
+	"github.com/jessecrossen/go81/render"
+)
+
+// make a small frame with text in a few colors
+func testFrame(text string) render.Frame {
+	f := render.NewFrame()
+	f.Draw(text, 0, 0, render.ColorRed, render.ColorBlue)
+	f.Draw("●", 1, 1, render.ColorGreen, render.ColorDefault)
+	return f
+}
+
+// check that an image has the same colors as a frame drawn directly
+func checkPixels(t *testing.T, img image.Image, f *render.Frame, cols int, rows int) {
+	t.Helper()
+	want := raster.Frame(f, cols, rows)
+	if img.Bounds() != want.Bounds() {
+		t.Fatalf("image is %v, want %v", img.Bounds(), want.Bounds())
+	}
+	for y := 0; y < want.Bounds().Dy(); y++ {
+		for x := 0; x < want.Bounds().Dx(); x++ {
+			r0, g0, b0, _ := img.At(x, y).RGBA()
+			r1, g1, b1, _ := want.At(x, y).RGBA()
+			if (r0 != r1) || (g0 != g1) || (b0 != b1) {
+				t.Fatalf("pixel %d,%d differs from the frame", x, y)
+			}
+		}
+	}
+}
+
+func TestPNGRoundTrip(t *testing.T) {
+	f := testFrame("Hi ?")
+	var b bytes.Buffer
+	if err := raster.WritePNG(&b, &f); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols, rows := f.Size()
+	if (img.Bounds().Dx() != cols*raster.CellWidth) || (img.Bounds().Dy() != rows*raster.CellHeight) {
+		t.Errorf("image is %v for a frame of %dx%d cells", img.Bounds(), cols, rows)
+	}
+	checkPixels(t, img, &f, cols, rows)
+}
+
+func TestGIFRoundTrip(t *testing.T) {
+	frames := []render.Frame{testFrame("one"), testFrame("two"), testFrame("three")}
+	anim := raster.Animation{}
+	anim.Add(frames[0], 0)
+	// the second frame is too short to show, so it's merged into the third
+	anim.Add(frames[1], time.Second)
+	anim.Add(frames[2], time.Second+5*time.Millisecond)
+	var b bytes.Buffer
+	if err := anim.WriteGIF(&b); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := gif.DecodeAll(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (len(decoded.Image) != 2) || (decoded.Delay[0] != 100) || (decoded.Delay[1] != 200) {
+		t.Fatalf("decoded %d images with delays %v, want 2 with [100 200]", len(decoded.Image), decoded.Delay)
+	}
+	cols, rows := frames[2].Size()
+	checkPixels(t, decoded.Image[0], &frames[0], cols, rows)
+	checkPixels(t, decoded.Image[1], &frames[2], cols, rows)
+}
+
+func TestWritePNGConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := testFrame("●○◑")
+			if err := raster.WritePNG(io.Discard, &f); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
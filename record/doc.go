@@ -0,0 +1,6 @@
+// Package record logs the inputs given to a game so the game can be replayed
+// exactly.
+//
+// A game is fully determined by its seed, the characters passed to
+// Game.Input and the ticks passed to Game.Step, so a Log stores only those.
+package record
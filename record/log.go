@@ -0,0 +1,142 @@
+package record
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// Version is the version of the log format written by Log.Write.
+const Version = 1
+
+// the first word of a log file
+const magic = "go81-replay"
+
+// NoInput marks an entry that only passes time.
+const NoInput rune = -1
+
+// An Entry is an input given to a game after some number of ticks.
+type Entry struct {
+	Ticks int  // the number of ticks since the previous entry
+	Input rune // the input character, or NoInput
+}
+
+// A Log stores everything that was passed to a game.
+type Log struct {
+	Seed       int64             // the seed the game was created with
+	Rules      string            // the name of the variant the game was played by, or empty for the classic rules
+	Difficulty engine.Difficulty // the difficulty level the game dealt cards for
+	Scoring    string            // the name of the scoring policy, or empty for classic scoring
+	Entries    []Entry           // inputs in the order they were given
+}
+
+// Ticks returns the total number of ticks in the log.
+func (l *Log) Ticks() int {
+	ticks := 0
+	for _, e := range l.Entries {
+		ticks += e.Ticks
+	}
+	return ticks
+}
+
+// Write the log in a compact line-based format.
+func (l *Log) Write(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "%s %d\n", magic, Version)
+	fmt.Fprintf(b, "seed %d\n", l.Seed)
+	if l.Rules != "" {
+		fmt.Fprintf(b, "rules %s\n", l.Rules)
+	}
+	if l.Difficulty != engine.DifficultyNormal {
+		fmt.Fprintf(b, "difficulty %s\n", l.Difficulty)
+	}
+	if l.Scoring != "" {
+		fmt.Fprintf(b, "scoring %s\n", l.Scoring)
+	}
+	for _, e := range l.Entries {
+		if e.Input == NoInput {
+			fmt.Fprintf(b, "%d\n", e.Ticks)
+		} else {
+			fmt.Fprintf(b, "%d %s\n", e.Ticks, strconv.QuoteRune(e.Input))
+		}
+	}
+	return b.Flush()
+}
+
+// Read a log written by Log.Write.
+func Read(r io.Reader) (Log, error) {
+	var l Log
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	nextLine := func() (string, bool) {
+		ok := scanner.Scan()
+		lineNumber++
+		return scanner.Text(), ok
+	}
+	// read the header
+	line, _ := nextLine()
+	var version int
+	if _, err := fmt.Sscanf(line, magic+" %d", &version); err != nil {
+		return l, fmt.Errorf("not a replay log")
+	}
+	if version != Version {
+		return l, fmt.Errorf("unsupported replay version %d", version)
+	}
+	line, _ = nextLine()
+	if _, err := fmt.Sscanf(line, "seed %d", &l.Seed); err != nil {
+		return l, fmt.Errorf("line %d: missing seed", lineNumber)
+	}
+	// read entries
+	for {
+		line, ok := nextLine()
+		if !ok {
+			break
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "rules ") && (len(l.Entries) == 0) {
+			l.Rules = strings.TrimPrefix(line, "rules ")
+			if _, ok := engine.Variants[l.Rules]; !ok {
+				return l, fmt.Errorf("line %d: unknown rules %q", lineNumber, l.Rules)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "difficulty ") && (len(l.Entries) == 0) {
+			name := strings.TrimPrefix(line, "difficulty ")
+			var ok bool
+			if l.Difficulty, ok = engine.Difficulties[name]; !ok {
+				return l, fmt.Errorf("line %d: unknown difficulty %q", lineNumber, name)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "scoring ") && (len(l.Entries) == 0) {
+			l.Scoring = strings.TrimPrefix(line, "scoring ")
+			if _, ok := engine.ScoringPolicies[l.Scoring]; !ok {
+				return l, fmt.Errorf("line %d: unknown scoring %q", lineNumber, l.Scoring)
+			}
+			continue
+		}
+		e := Entry{Input: NoInput}
+		fields := strings.SplitN(line, " ", 2)
+		ticks, err := strconv.Atoi(fields[0])
+		if err != nil || ticks < 0 {
+			return l, fmt.Errorf("line %d: invalid tick count %q", lineNumber, fields[0])
+		}
+		e.Ticks = ticks
+		if len(fields) > 1 {
+			input, err := strconv.Unquote(fields[1])
+			runes := []rune(input)
+			if err != nil || len(runes) != 1 {
+				return l, fmt.Errorf("line %d: invalid input %q", lineNumber, fields[1])
+			}
+			e.Input = runes[0]
+		}
+		l.Entries = append(l.Entries, e)
+	}
+	return l, scanner.Err()
+}
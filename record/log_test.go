@@ -0,0 +1,173 @@
+package record_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/record"
+	"github.com/jessecrossen/go81/solver"
+)
+
+// the outcome of a game
+type outcome struct {
+	score   int
+	sets    int
+	ticks   int
+	elapsed time.Duration
+}
+
+// get the inputs that select the first set on the table, or nil if there is none
+func firstSetInputs(g *engine.Game) []rune {
+	sets := solver.Sets(engine.TableIDs(g.Table()))
+	if len(sets) == 0 {
+		return nil
+	}
+	set := sets[0]
+	return []rune{rune('a' + set[0]), rune('a' + set[1]), rune('a' + set[2])}
+}
+
+// count the sets found in a game from now on
+func countSets(g *engine.Game, sets *int) {
+	g.Subscribe(func(e engine.Event) {
+		if _, ok := e.(engine.SetFound); ok {
+			*sets++
+		}
+	})
+}
+
+// play a game with a recorder, pausing, making a mistake and then finding
+// sets, and return its outcome and the log
+func recordGame(t *testing.T, g *engine.Game, sets int) (outcome, record.Log) {
+	t.Helper()
+	recorder := record.NewRecorder(g)
+	result := outcome{}
+	countSets(g, &result.sets)
+	step := func(ticks int) {
+		for i := 0; i < ticks; i++ {
+			recorder.Tick()
+			g.Step()
+			result.ticks++
+		}
+	}
+	input := func(inputs []rune) {
+		for _, c := range inputs {
+			g.Input(c)
+			recorder.Input(c)
+		}
+	}
+	step(200)
+	input([]rune(" "))
+	step(10)
+	input([]rune(" "))
+	step(10)
+	if !engine.AreSet(g.Table()[0], g.Table()[1], g.Table()[2]) {
+		input([]rune("abc"))
+		step(50)
+	}
+	for i := 0; i < sets; i++ {
+		inputs := firstSetInputs(g)
+		if inputs == nil {
+			t.Fatalf("no set on the table after %d sets", i)
+		}
+		input(inputs)
+		step(150)
+	}
+	result.score = g.Score()
+	result.elapsed = g.Elapsed()
+	return result, recorder.Log()
+}
+
+// play a log back to the end and return its outcome
+func playBack(log record.Log) outcome {
+	result := outcome{}
+	player := record.NewPlayer(log)
+	countSets(player.Game(), &result.sets)
+	for {
+		if _, ok := player.Step(); !ok {
+			break
+		}
+	}
+	result.score = player.Game().Score()
+	result.ticks = player.Tick()
+	result.elapsed = player.Game().Elapsed()
+	return result
+}
+
+func TestLogRoundTrip(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		game  func() *engine.Game
+		lines []string
+	}{
+		{"classic", func() *engine.Game {
+			return engine.NewGame(1)
+		}, nil},
+		{"variant", func() *engine.Game {
+			return engine.NewGameWithRules(1, engine.JuniorRules)
+		}, []string{"rules junior"}},
+		{"difficulty", func() *engine.Game {
+			return engine.NewGameWithDifficulty(1, engine.ClassicRules{}, engine.DifficultyHard)
+		}, []string{"difficulty hard"}},
+		{"scoring", func() *engine.Game {
+			g := engine.NewGame(1)
+			g.SetScoring(engine.StreakScoring{})
+			return g
+		}, []string{"scoring streak"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			want, log := recordGame(t, c.game(), 4)
+			if want.sets != 4 {
+				t.Fatalf("found %d sets while recording, want 4", want.sets)
+			}
+			var b bytes.Buffer
+			if err := log.Write(&b); err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range c.lines {
+				if !strings.Contains(b.String(), "\n"+line+"\n") {
+					t.Errorf("log is missing %q:\n%s", line, b.String())
+				}
+			}
+			read, err := record.Read(&b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(read, log) {
+				t.Errorf("read %+v, want %+v", read, log)
+			}
+			if got := playBack(read); got != want {
+				t.Errorf("replay gave %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		log  string
+	}{
+		{"empty", ""},
+		{"not a log", "hello\nseed 1\n"},
+		{"bad version", "go81-replay 99\nseed 1\n"},
+		{"missing seed", "go81-replay 1\n200\n"},
+		{"unknown rules", "go81-replay 1\nseed 1\nrules nope\n"},
+		{"unknown difficulty", "go81-replay 1\nseed 1\ndifficulty nope\n"},
+		{"unknown scoring", "go81-replay 1\nseed 1\nscoring nope\n"},
+		{"rules after entries", "go81-replay 1\nseed 1\n200\nrules junior\n"},
+		{"bad tick count", "go81-replay 1\nseed 1\nsoon 'a'\n"},
+		{"negative tick count", "go81-replay 1\nseed 1\n-1 'a'\n"},
+		{"bad input", "go81-replay 1\nseed 1\n200 a\n"},
+		{"several inputs", "go81-replay 1\nseed 1\n200 \"ab\"\n"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := record.Read(strings.NewReader(c.log)); err == nil {
+				t.Error("read without an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package record
+
+import (
+	"fmt"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// A Player feeds a log back through a new game one tick at a time.
+type Player struct {
+	game  *engine.Game
+	log   Log
+	next  int // the index of the next entry to apply
+	ticks int // ticks since the last entry was applied
+	tick  int // ticks since the start of the game
+	// Strict makes the player stop at the first input the game would ignore
+	Strict bool
+	err    error // why playback stopped early
+}
+
+// NewPlayer creates a player for the given log.
+func NewPlayer(log Log) *Player {
+	rules, ok := engine.Variants[log.Rules]
+	if !ok {
+		rules = engine.ClassicRules{}
+	}
+	game := engine.NewGameWithDifficulty(log.Seed, rules, log.Difficulty)
+	if scoring, ok := engine.ScoringPolicies[log.Scoring]; ok {
+		game.SetScoring(scoring)
+	}
+	return &Player{
+		game: game,
+		log:  log,
+	}
+}
+
+// Game returns the game the log is being played back through.
+func (p *Player) Game() *engine.Game {
+	return p.game
+}
+
+// Tick returns the number of ticks played back so far.
+func (p *Player) Tick() int {
+	return p.tick
+}
+
+// Step applies any inputs due before the next tick and then advances the game by one tick.
+// It returns whether the game needs to be rendered and whether there was a tick left to play.
+func (p *Player) Step() (needsRender bool, ok bool) {
+	for (p.next < len(p.log.Entries)) && (p.log.Entries[p.next].Ticks == p.ticks) {
+		if input := p.log.Entries[p.next].Input; input != NoInput {
+			if p.Strict {
+				if err := p.game.CheckInput(input); err != nil {
+					p.err = fmt.Errorf("tick %d: impossible input %q: %v", p.tick, input, err)
+					p.next = len(p.log.Entries)
+					return false, false
+				}
+			}
+			p.game.Input(input)
+		}
+		p.next++
+		p.ticks = 0
+	}
+	if p.next >= len(p.log.Entries) {
+		return false, false
+	}
+	p.ticks++
+	p.tick++
+	return p.game.Step(), true
+}
+
+// Err returns why playback stopped before the end of the log, or nil.
+func (p *Player) Err() error {
+	return p.err
+}
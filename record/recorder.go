@@ -0,0 +1,49 @@
+package record
+
+import "github.com/jessecrossen/go81/engine"
+
+// A Recorder builds a log as inputs and ticks are passed to a game.
+type Recorder struct {
+	log   Log
+	ticks int // ticks since the last entry
+}
+
+// NewRecorder creates a recorder for a new game.
+func NewRecorder(g *engine.Game) *Recorder {
+	log := Log{Seed: g.Seed(), Difficulty: g.Difficulty()}
+	if _, classic := g.Rules().(engine.ClassicRules); !classic {
+		log.Rules = g.Rules().Name()
+	}
+	if _, classic := g.Scoring().(engine.ClassicScoring); !classic {
+		log.Scoring = g.Scoring().Name()
+	}
+	return &Recorder{
+		log: log,
+	}
+}
+
+// Input records an input character passed to Game.Input.
+func (r *Recorder) Input(c rune) {
+	r.log.Entries = append(r.log.Entries, Entry{Ticks: r.ticks, Input: c})
+	r.ticks = 0
+}
+
+// Tick records a call to Game.Step.
+func (r *Recorder) Tick() {
+	r.ticks++
+}
+
+// Log returns everything recorded so far.
+func (r *Recorder) Log() Log {
+	log := Log{
+		Seed:       r.log.Seed,
+		Rules:      r.log.Rules,
+		Difficulty: r.log.Difficulty,
+		Scoring:    r.log.Scoring,
+		Entries:    append([]Entry(nil), r.log.Entries...),
+	}
+	if r.ticks > 0 {
+		log.Entries = append(log.Entries, Entry{Ticks: r.ticks, Input: NoInput})
+	}
+	return log
+}
@@ -0,0 +1,57 @@
+package record
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// A Result summarizes the outcome of a recorded game.
+type Result struct {
+	Score    int           // the final score
+	Sets     int           // the number of sets found
+	Duration time.Duration // the amount of game time played
+}
+
+// Simulate plays a log back through a game with no display and returns its outcome.
+// It returns an error if the log contains an input the game would have ignored.
+func Simulate(log Log) (Result, error) {
+	var result Result
+	player := NewPlayer(log)
+	player.Strict = true
+	player.Game().Subscribe(func(e engine.Event) {
+		if _, ok := e.(engine.SetFound); ok {
+			result.Sets++
+		}
+	})
+	for {
+		if _, ok := player.Step(); !ok {
+			break
+		}
+	}
+	if err := player.Err(); err != nil {
+		return result, err
+	}
+	result.Score = player.Game().Score()
+	result.Duration = player.Game().Elapsed()
+	return result, nil
+}
+
+// Verify plays a log back with no display and confirms that it produces the claimed outcome.
+func Verify(log Log, claim Result) error {
+	result, err := Simulate(log)
+	if err != nil {
+		return err
+	}
+	if result.Score != claim.Score {
+		return fmt.Errorf("claimed a score of %d but the game scored %d", claim.Score, result.Score)
+	}
+	if result.Sets != claim.Sets {
+		return fmt.Errorf("claimed %d sets but the game found %d", claim.Sets, result.Sets)
+	}
+	if result.Duration != claim.Duration {
+		return fmt.Errorf("claimed a duration of %v but the game took %v", claim.Duration, result.Duration)
+	}
+	return nil
+}
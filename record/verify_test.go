@@ -0,0 +1,63 @@
+package record_test
+
+import (
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/record"
+)
+
+func TestVerifyClaims(t *testing.T) {
+	played, log := recordGame(t, engine.NewGame(1), 4)
+	claim := record.Result{
+		Score:    played.score,
+		Sets:     played.sets,
+		Duration: played.elapsed,
+	}
+	result, err := record.Simulate(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != claim {
+		t.Errorf("simulated %+v, want %+v", result, claim)
+	}
+	wrongScore, wrongSets, wrongDuration := claim, claim, claim
+	wrongScore.Score++
+	wrongSets.Sets--
+	wrongDuration.Duration += engine.TickDuration
+	for _, c := range []struct {
+		name  string
+		claim record.Result
+		ok    bool
+	}{
+		{"right", claim, true},
+		{"wrong score", wrongScore, false},
+		{"wrong sets", wrongSets, false},
+		{"wrong duration", wrongDuration, false},
+	} {
+		if err := record.Verify(log, c.claim); (err == nil) != c.ok {
+			t.Errorf("%s claim: got error %v, want ok %v", c.name, err, c.ok)
+		}
+	}
+}
+
+func TestVerifyImpossibleInputs(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		entries []record.Entry
+	}{
+		{"unrecognized key", []record.Entry{{Ticks: 200, Input: '!'}}},
+		{"card before dealing", []record.Entry{{Ticks: 0, Input: 'a'}}},
+		{"empty position", []record.Entry{{Ticks: 200, Input: 'u'}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			log := record.Log{Seed: 1, Entries: append(c.entries, record.Entry{Ticks: 10, Input: record.NoInput})}
+			if _, err := record.Simulate(log); err == nil {
+				t.Error("simulated without an error")
+			}
+			if err := record.Verify(log, record.Result{}); err == nil {
+				t.Error("verified without an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package render
+
+import "github.com/jessecrossen/go81/engine"
+
+// DrawCard draws a card into the frame at its current position.
+func DrawCard(f *Frame, c *engine.Card, theme Theme) {
+	col, row := c.Position()
+	outlineColor := theme.Outline
+	if c.Selected() {
+		outlineColor = theme.SelectedOutline
+	}
+	shrink, turn := normalizedShrinkAndTurn(c.Shrink(), c.Turn())
+	f.Draw(renderOutline(shrink, turn), col, row, outlineColor, ColorDefault)
+	if shrink == 0 {
+		if (turn <= 1 || turn >= 7) && (c.Dots() != 0) {
+			drawDots(f, c.Dots(), col+1, row+1, theme)
+		} else if turn <= 1 || turn >= 7 {
+			f.Draw(renderFace(c, theme), col+2, row+1, faceColor(c, theme), ColorDefault)
+		} else if turn >= 3 && turn <= 5 {
+			f.Draw(renderBack(), col+2, row+1, outlineColor, ColorDefault)
+		}
+	}
+}
+
+// DrawCardBack draws the back of a card at the given position.
+func DrawCardBack(f *Frame, col coord, row coord, theme Theme) {
+	f.Draw(renderOutline(0, engine.BackTurn), col, row, theme.Outline, ColorDefault)
+	f.Draw(renderBack(), col+2, row+1, theme.Outline, ColorDefault)
+}
+
+// get the color for the card's face symbols
+func faceColor(c *engine.Card, theme Theme) Color {
+	_, _, _, clr := c.Attributes()
+	if clr >= 0 && clr < len(theme.FaceColors) {
+		return theme.FaceColors[clr]
+	}
+	return ColorDefault
+}
+
+// limit the range of the animation parameters
+func normalizedShrinkAndTurn(shrink, turn int) (int, int) {
+	turn = max(0, turn%8)
+	shrink = min(max(0, shrink), engine.MaxShrink)
+	return shrink, turn
+}
+
+// get the outline of a card at the given stage of animation
+func renderOutline(shrink, turn int) string {
+	// the turn animation sequence is mirrored to get a full flip
+	//	and repeated for the front and back of the card
+	//	 turn:    0 1 2 3 4 5 6 7 8
+	//	 outline: 0 1 2 1 0 1 2 1 0
+	turn = turn % 4
+	if turn > 2 {
+		turn = 4 - turn
+	}
+	switch shrink {
+	case 0:
+		switch turn {
+		case 0:
+			return "" +
+				"╭───╮\n" +
+				"│   │\n" +
+				"│   │\n" +
+				"│   │\n" +
+				"╰───╯"
+		case 1:
+			return "" +
+				" ╭─╮\n" +
+				" │ │\n" +
+				" │ │\n" +
+				" │ │\n" +
+				" ╰─╯"
+		default:
+			return "" +
+				"  ╷\n" +
+				"  │\n" +
+				"  │\n" +
+				"  │\n" +
+				"  ╵"
+		}
+	case 1:
+		switch turn {
+		case 0:
+			return "" +
+				"╭──╮\n" +
+				"│  │\n" +
+				"│  │\n" +
+				"╰──╯"
+		case 1:
+			return "" +
+				" ╭─╮\n" +
+				" │ │\n" +
+				" │ │\n" +
+				" ╰─╯"
+		default:
+			return "" +
+				" ╷\n" +
+				" │\n" +
+				" │\n" +
+				" ╵"
+		}
+	case 2:
+		switch turn {
+		case 0:
+			return "" +
+				"╭─╮\n" +
+				"│ │\n" +
+				"╰─╯"
+		default:
+			return "" +
+				" ╷\n" +
+				" │\n" +
+				" ╵"
+		}
+	case 3:
+		switch turn {
+		case 0:
+			return "" +
+				"┌┐\n" +
+				"└┘"
+		default:
+			return "" +
+				"╷\n" +
+				"╵"
+		}
+	case 4:
+		switch turn {
+		case 0:
+			return "▯"
+		default:
+			return "│"
+		}
+	default:
+		return "·"
+	}
+}
+
+func renderFace(c *engine.Card, theme Theme) string {
+	count, shape, fill, _ := c.Attributes()
+	symbol := theme.Symbols[shape][fill]
+	switch count {
+	case 1:
+		return "\n" + symbol
+	case 2:
+		return symbol + "\n\n" + symbol
+	case 3:
+		return symbol + "\n" + symbol + "\n" + symbol
+	}
+	return ""
+}
+
+// draw the dots of a ProSET card into the 3x3 region inside its outline,
+// as two columns of three with the first dot at the top left
+func drawDots(f *Frame, dots int, col coord, row coord, theme Theme) {
+	for bit := 0; bit < engine.ProSetDots; bit++ {
+		if dots&(1<<bit) != 0 {
+			f.Draw(theme.Dot, col+(bit%2)*2, row+bit/2, theme.DotColors[bit], ColorDefault)
+		}
+	}
+}
+
+func renderBack() string {
+	return "\n?"
+}
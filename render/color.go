@@ -0,0 +1,53 @@
+package render
+
+import "fmt"
+
+// DefaultForeground is the console color text is usually shown in when drawn with ColorDefault.
+const DefaultForeground = ColorLightGray
+
+// DefaultBackground is the console color usually behind text drawn with ColorDefault.
+const DefaultBackground = ColorBlack
+
+// ConsoleColors lists the 16 console colors in palette order.
+var ConsoleColors = []Color{
+	ColorBlack, ColorRed, ColorGreen, ColorYellow,
+	ColorBlue, ColorMagenta, ColorCyan, ColorLightGray,
+	ColorDarkGray, ColorLightRed, ColorLightGreen, ColorLightYellow,
+	ColorLightBlue, ColorLightMagenta, ColorLightCyan, ColorWhite,
+}
+
+// the red, green and blue components of each console color, as shown by xterm
+var consoleRGB = map[Color][3]uint8{
+	ColorBlack:        {0x00, 0x00, 0x00},
+	ColorRed:          {0xcd, 0x00, 0x00},
+	ColorGreen:        {0x00, 0xcd, 0x00},
+	ColorYellow:       {0xcd, 0xcd, 0x00},
+	ColorBlue:         {0x00, 0x00, 0xee},
+	ColorMagenta:      {0xcd, 0x00, 0xcd},
+	ColorCyan:         {0x00, 0xcd, 0xcd},
+	ColorLightGray:    {0xe5, 0xe5, 0xe5},
+	ColorDarkGray:     {0x7f, 0x7f, 0x7f},
+	ColorLightRed:     {0xff, 0x00, 0x00},
+	ColorLightGreen:   {0x00, 0xff, 0x00},
+	ColorLightYellow:  {0xff, 0xff, 0x00},
+	ColorLightBlue:    {0x5c, 0x5c, 0xff},
+	ColorLightMagenta: {0xff, 0x00, 0xff},
+	ColorLightCyan:    {0x00, 0xff, 0xff},
+	ColorWhite:        {0xff, 0xff, 0xff},
+}
+
+// RGB returns the red, green and blue components of a console color,
+// using the given color in place of ColorDefault.
+func RGB(c Color, defaultColor Color) (r, g, b uint8) {
+	rgb, ok := consoleRGB[c]
+	if !ok {
+		rgb = consoleRGB[defaultColor]
+	}
+	return rgb[0], rgb[1], rgb[2]
+}
+
+// get a CSS hex color for a console color
+func hexColor(c Color, defaultColor Color) string {
+	r, g, b := RGB(c, defaultColor)
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
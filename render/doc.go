@@ -0,0 +1,5 @@
+// Package render draws games into Frames, which are blocks of colored text
+// that can be written to a terminal.
+//
+// A Theme controls the colors and symbols used to draw cards and the table.
+package render
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// DrawEffects draws transient effects over everything in the frame.
+func DrawEffects(f *Frame, effects []engine.Effect, theme Theme) {
+	for _, effect := range effects {
+		switch effect.Kind {
+		case engine.EffectSparkle:
+			drawSparkle(f, effect, theme)
+		case engine.EffectFloat:
+			drawFloat(f, effect, theme)
+		case engine.EffectFlash:
+			drawFlash(f, effect, theme)
+		}
+	}
+}
+
+// the characters a sparkle cycles through
+var sparkleGlyphs = []rune("·✧✦✧")
+
+// points around the outside of a card where sparkles can appear
+var sparklePoints = [][2]coord{
+	{-1, 0}, {engine.CardWidth, 1}, {2, -1}, {-1, 3},
+	{engine.CardWidth, engine.CardHeight - 1}, {1, engine.CardHeight},
+	{engine.CardWidth - 1, -1}, {-1, engine.CardHeight - 1},
+	{3, engine.CardHeight}, {engine.CardWidth, 3},
+}
+
+// twinkle around the edges of a card
+func drawSparkle(f *Frame, effect engine.Effect, theme Theme) {
+	for i, point := range sparklePoints {
+		if (i+effect.Step)%3 != 0 {
+			continue
+		}
+		col, row := effect.Col+point[0], effect.Row+point[1]
+		if col < 0 || row < 0 {
+			continue
+		}
+		glyph := sparkleGlyphs[(i+effect.Step)%len(sparkleGlyphs)]
+		f.Draw(string(glyph), col, row, theme.Sparkle, ColorDefault)
+	}
+}
+
+// float a change in score upward
+func drawFloat(f *Frame, effect engine.Effect, theme Theme) {
+	fg := theme.Gain
+	if effect.Value < 0 {
+		fg = theme.Loss
+	}
+	f.Draw(fmt.Sprintf("%+d", effect.Value), effect.Col, max(0, effect.Row-effect.Step),
+		fg, ColorDefault)
+}
+
+// flash the whole frame on the first step
+func drawFlash(f *Frame, effect engine.Effect, theme Theme) {
+	if effect.Step == 0 {
+		f.Tint(theme.FlashForeground, theme.FlashBackground)
+	}
+}
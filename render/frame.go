@@ -1,4 +1,4 @@
-package main
+package render
 
 import (
 	"fmt"
@@ -8,8 +8,8 @@ import (
 // a row or column index.
 type coord = int
 
-// a color constant
-type color = int8
+// Color is a console color constant.
+type Color = int8
 
 // the maximum number of rows in a frame
 const maxRows coord = 40
@@ -17,45 +17,75 @@ const maxRows coord = 40
 // A Frame represents a block of text to render to the terminal.
 type Frame struct {
 	lines  [][]rune
-	colors [][]string
+	colors [][]cellColor
 }
 
+// the colors of a single character in a frame
+type cellColor struct {
+	fg Color
+	bg Color
+}
+
+// the colors of characters nothing has been drawn to
+var defaultCellColor = cellColor{ColorDefault, ColorDefault}
+
 // NewFrame returns a Frame struct with no content.
 func NewFrame() Frame {
 	return Frame{
 		lines:  make([][]rune, 0, maxRows),
-		colors: make([][]string, 0, maxRows),
+		colors: make([][]cellColor, 0, maxRows),
 	}
 }
 
 // Draw a set of newline-delimited lines to the given coordinates in the frame.
-func (f *Frame) Draw(text string, col coord, row coord, fg color, bg color) {
+func (f *Frame) Draw(text string, col coord, row coord, fg Color, bg Color) {
 	drawLines := strings.Split(text, "\n")
 	f.ensureRowCount(row + len(drawLines))
 	for i, drawLine := range drawLines {
 		drawRunes := []rune(drawLine)
 		lineIndex := row + i
+		if lineIndex < 0 {
+			continue
+		}
 		if lineIndex < cap(f.lines) {
 			f.lines[lineIndex] = insertStringInLine(f.lines[lineIndex], drawRunes, col)
 		}
 		if lineIndex < cap(f.colors) {
 			f.colors[lineIndex] = insertColorInLine(f.colors[lineIndex],
-				changeColor(fg, bg), col, len(drawRunes))
+				cellColor{fg, bg}, col, len(drawRunes))
 		}
 	}
 }
 
-// NewDisplay returns a channel which accepts frames and sends them to the terminal.
-func NewDisplay() chan<- Frame {
-	display := make(chan Frame)
-	go func() {
-		lastFrame := NewFrame()
-		for thisFrame := range display {
-			fmt.Print(thisFrame.Replace(lastFrame))
-			lastFrame = thisFrame
+// Tint changes the colors of everything drawn into the frame so far.
+func (f *Frame) Tint(fg Color, bg Color) {
+	tint := cellColor{fg, bg}
+	for _, line := range f.colors {
+		for i := range line {
+			line[i] = tint
 		}
-	}()
-	return display
+	}
+}
+
+// Size returns the width of the widest line in the frame and the number of lines.
+func (f *Frame) Size() (cols coord, rows coord) {
+	for _, line := range f.lines {
+		cols = max(cols, len(line))
+	}
+	return cols, len(f.lines)
+}
+
+// Cell returns the character and colors at the given position in the frame,
+// which is a space in default colors if nothing was drawn there.
+func (f *Frame) Cell(col coord, row coord) (r rune, fg Color, bg Color) {
+	r, c := ' ', defaultCellColor
+	if (row >= 0) && (row < len(f.lines)) && (col >= 0) && (col < len(f.lines[row])) {
+		r = f.lines[row][col]
+	}
+	if (row >= 0) && (row < len(f.colors)) && (col >= 0) && (col < len(f.colors[row])) {
+		c = f.colors[row][col]
+	}
+	return r, c.fg, c.bg
 }
 
 // Render a frame to a string that can be written to the terminal.
@@ -92,23 +122,23 @@ func (f *Frame) Replace(old Frame) string {
 
 // console colors (offset from 30 for foreground and 40 for background)
 const (
-	ColorBlack        color = 0
-	ColorRed          color = 1
-	ColorGreen        color = 2
-	ColorYellow       color = 3
-	ColorBlue         color = 4
-	ColorMagenta      color = 5
-	ColorCyan         color = 6
-	ColorLightGray    color = 7
-	ColorDefault      color = 9
-	ColorDarkGray     color = 60
-	ColorLightRed     color = 61
-	ColorLightGreen   color = 62
-	ColorLightYellow  color = 63
-	ColorLightBlue    color = 64
-	ColorLightMagenta color = 65
-	ColorLightCyan    color = 66
-	ColorWhite        color = 67
+	ColorBlack        Color = 0
+	ColorRed          Color = 1
+	ColorGreen        Color = 2
+	ColorYellow       Color = 3
+	ColorBlue         Color = 4
+	ColorMagenta      Color = 5
+	ColorCyan         Color = 6
+	ColorLightGray    Color = 7
+	ColorDefault      Color = 9
+	ColorDarkGray     Color = 60
+	ColorLightRed     Color = 61
+	ColorLightGreen   Color = 62
+	ColorLightYellow  Color = 63
+	ColorLightBlue    Color = 64
+	ColorLightMagenta Color = 65
+	ColorLightCyan    Color = 66
+	ColorWhite        Color = 67
 )
 
 // IMPLEMENTATION *************************************************************
@@ -119,7 +149,7 @@ func (f *Frame) ensureRowCount(rows coord) {
 		f.lines = append(f.lines, make([]rune, 0))
 	}
 	for i := len(f.colors); i < min(rows, cap(f.colors)); i++ {
-		f.colors = append(f.colors, make([]string, 0))
+		f.colors = append(f.colors, make([]cellColor, 0))
 	}
 }
 
@@ -142,17 +172,16 @@ func insertStringInLine(line []rune, s []rune, col coord) []rune {
 }
 
 // insert color changes into a line
-func insertColorInLine(line []string, color string, col coord, width coord) []string {
+func insertColorInLine(line []cellColor, color cellColor, col coord, width coord) []cellColor {
 	if width == 0 {
 		return line
 	}
 	afterInsertedIndex := col + width
 	if afterInsertedIndex > len(line) {
-		newLine := make([]string, afterInsertedIndex)
+		newLine := make([]cellColor, afterInsertedIndex)
 		copy(newLine, line)
-		fill := changeColor(ColorDefault, ColorDefault)
 		for i := len(line); i < len(newLine); i++ {
-			newLine[i] = fill
+			newLine[i] = defaultCellColor
 		}
 		line = newLine
 	}
@@ -173,14 +202,14 @@ func (f *Frame) renderLine(b *strings.Builder, row coord) {
 		return
 	}
 	colors := f.colors[row]
-	lastColor := ""
+	lastColor := cellColor{-1, -1}
 	lastIndex := 0
 	minLen := min(len(colors), len(line))
 	for i := 0; i < minLen; i++ {
 		thisColor := colors[i]
 		if thisColor != lastColor {
 			b.WriteString(string(line[lastIndex:i]))
-			b.WriteString(thisColor)
+			b.WriteString(changeColor(thisColor.fg, thisColor.bg))
 			lastColor = thisColor
 			lastIndex = i
 		}
@@ -203,7 +232,7 @@ func cursorUp(lines int) string {
 	return fmt.Sprintf("%s[%dA", escape, lines)
 }
 
-func changeColor(fg, bg color) string {
+func changeColor(fg, bg Color) string {
 	// return fmt.Sprintf("%s[%dm%s[%dm", escape, 39+fg, escape, 49+bg)
 	return fmt.Sprintf("%s[%d;%dm", escape, 30+fg, 40+bg)
 }
@@ -0,0 +1,250 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// Game renders the current state of a game to a frame buffer.
+func Game(g *engine.Game, theme Theme) Frame {
+	f := NewFrame()
+	if !g.Paused() {
+		renderLetters(&f, g, theme)
+	}
+	if g.Puzzle() != nil {
+		renderFoundSets(&f, g, theme)
+	} else if g.DrillLevel() > 0 {
+		renderDrill(&f, g, theme)
+	} else if g.TrainerStats() != nil {
+		renderTrainer(&f, g, theme)
+	} else {
+		renderPiles(&f, g, theme)
+	}
+	renderCards(&f, g, theme)
+	renderScore(&f, g, theme)
+	if g.Paused() {
+		renderPause(&f, theme)
+	} else if g.Over() {
+		renderGameOver(&f, theme)
+	} else {
+		DrawEffects(&f, g.Effects(), theme)
+	}
+	return f
+}
+
+// draw cards in layers from back to front
+func renderCards(f *Frame, g *engine.Game, theme Theme) {
+	cards := g.Cards()
+	cardsTotal := len(cards)
+	cardsFound := 0
+	layer := 0
+	for {
+		for i := 0; i < cardsTotal; i++ {
+			card := &cards[i]
+			if card.Layer() == layer {
+				cardsFound++
+				if layer > 0 {
+					DrawCard(f, card, theme)
+				}
+			}
+		}
+		layer++
+		// stop iterating if we've found all cards or the layer index gets too high
+		if (cardsFound >= cardsTotal) || (layer >= 100) {
+			break
+		}
+	}
+}
+
+// draw letters marking each card position
+func renderLetters(f *Frame, g *engine.Game, theme Theme) {
+	for i, card := range g.Table() {
+		if card != nil && card.Layer() == engine.LayerDealt {
+			color := theme.Letter
+			if card.Selected() {
+				color = theme.SelectedLetter
+			}
+			col, row := letterCoords(i)
+			f.Draw(fmt.Sprintf("%c", 'A'+i), col, row, color, ColorDefault)
+		}
+	}
+}
+
+// draw the pile of cards left to deal and the pile of collected sets
+func renderPiles(f *Frame, g *engine.Game, theme Theme) {
+	remaining := g.Remaining()
+	col, row := engine.DrawPileCoords()
+	if remaining > 0 {
+		DrawCardBack(f, col, row, theme)
+	}
+	f.Draw(fmt.Sprintf("%d", remaining), col+engine.CardWidth+1, row+(engine.CardHeight/2),
+		theme.DimText, ColorDefault)
+	// stack one card for each set collected, up to a limit
+	col, row = engine.CollectedPileCoords()
+	for i := 0; i < min(g.SetsCollected(), maxPileStack); i++ {
+		DrawCardBack(f, col+i, row, theme)
+	}
+}
+
+// list the sets found in a puzzle beside the table, drawing each card as its symbols
+func renderFoundSets(f *Frame, g *engine.Game, theme Theme) {
+	col, row := foundSetsCoords(len(g.Puzzle().Cards))
+	found := g.FoundSets()
+	f.Draw(fmt.Sprintf("Found %d of %d", len(found), g.Puzzle().Sets()), col, row,
+		theme.Text, ColorDefault)
+	cards := g.Cards()
+	for i, set := range found {
+		x := col
+		for _, id := range set {
+			card := &cards[id]
+			count, shape, fill, _ := card.Attributes()
+			f.Draw(strings.Repeat(theme.Symbols[shape][fill], count), x, row+2+i,
+				faceColor(card, theme), ColorDefault)
+			x += 4
+		}
+	}
+}
+
+// show the drill level and how the player is doing with each attribute beside
+// the candidates, and ask for the card that completes the set of the two shown
+func renderDrill(f *Frame, g *engine.Game, theme Theme) {
+	col, row := foundSetsCoords(engine.DrillChoices)
+	f.Draw(fmt.Sprintf("Level %d of %d", g.DrillLevel(), engine.MaxDrillLevel), col, row,
+		theme.Text, ColorDefault)
+	f.Draw("        right   time", col, row+2, theme.DimText, ColorDefault)
+	for i, stats := range g.DrillStats() {
+		f.Draw(fmt.Sprintf("%-6s %3d/%-3d %5.1fs", engine.AttributeNames[i], stats.Correct, stats.Asked,
+			stats.MeanTime().Seconds()), col, row+3+i, theme.Text, ColorDefault)
+	}
+	col, row = engine.DrillPromptCoords(2)
+	f.Draw("= ?", col, row+(engine.CardHeight/2), theme.Text, ColorDefault)
+}
+
+// ask whether the cards shown form a set, say what was wrong with the last
+// cards shown, and show how often the player gets each attribute wrong
+func renderTrainer(f *Frame, g *engine.Game, theme Theme) {
+	col, row := engine.TableCoords(1)
+	f.Draw(fmt.Sprintf("Is this a set? Press %c or %c", engine.TrainerYes, engine.TrainerNo), col, row+1,
+		theme.Text, ColorDefault)
+	if answered, right, failing := g.TrainerFeedback(); answered {
+		color, feedback := theme.Gain, "Right"
+		if !right {
+			color, feedback = theme.Loss, "Wrong"
+		}
+		if len(failing) == 0 {
+			feedback += ", the last cards were a set"
+		} else {
+			names := make([]string, len(failing))
+			for i, attribute := range failing {
+				names[i] = engine.AttributeNames[attribute]
+			}
+			feedback += ", the last cards failed on " + strings.Join(names, " and ")
+		}
+		f.Draw(feedback, col, row+2, color, ColorDefault)
+	}
+	col, row = foundSetsCoords(9)
+	f.Draw("Missed", col, row, theme.Text, ColorDefault)
+	for i, stats := range g.TrainerStats() {
+		missed := "  -"
+		if stats.Asked > 0 {
+			missed = fmt.Sprintf("%3.0f%%", (1-stats.Accuracy())*100)
+		}
+		f.Draw(fmt.Sprintf("%-6s %s", engine.AttributeNames[i], missed), col, row+2+i, theme.Text, ColorDefault)
+	}
+}
+
+// render the player's current score
+func renderScore(f *Frame, g *engine.Game, theme Theme) {
+	col, row := scoreCoords()
+	f.Draw(fmt.Sprintf("Score: %d", g.Score()), col, row, theme.Text, ColorDefault)
+	seconds := g.Elapsed() / time.Second
+	f.Draw(fmt.Sprintf("Time:  %d:%02d", seconds/60, seconds%60), col, row+1,
+		theme.DimText, ColorDefault)
+	if breakdown := scoreBreakdown(g.Points()); breakdown != "" {
+		f.Draw(breakdown, col, row+2, theme.DimText, ColorDefault)
+	}
+}
+
+// describe where the points in a score came from, or return an empty string
+// if they all came from one place
+func scoreBreakdown(p engine.Points) string {
+	parts := make([]string, 0)
+	for _, part := range []struct {
+		points int
+		name   string
+	}{
+		{p.Base, "base"},
+		{p.Bonus, "bonus"},
+		{p.Streak, "streak"},
+		{p.Speed, "speed"},
+		{p.Penalty, "penalty"},
+	} {
+		if part.points != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.points, part.name))
+		}
+	}
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.Join(parts, ", ")
+}
+
+// draw a notice over the table while the game is paused
+func renderPause(f *Frame, theme Theme) {
+	col, row := noticeCoords()
+	f.Draw(""+
+		"╭─────────────────────────╮\n"+
+		"│ Paused                  │\n"+
+		"│ press space to continue │\n"+
+		"╰─────────────────────────╯",
+		col, row, theme.Notice, ColorDefault)
+}
+
+// draw a notice over the table once the game is over
+func renderGameOver(f *Frame, theme Theme) {
+	col, row := noticeCoords()
+	f.Draw(""+
+		"╭─────────────────────────╮\n"+
+		"│ Game over               │\n"+
+		"│ press q to quit         │\n"+
+		"╰─────────────────────────╯",
+		col, row, theme.Notice, ColorDefault)
+}
+
+// LAYOUT *********************************************************************
+
+// maximum number of cards to draw stacked in the collected pile
+const maxPileStack = 3
+
+// the coords of the letter marking the given index in the table
+func letterCoords(i int) (col coord, row coord) {
+	col, row = engine.TableCoords(i)
+	col += engine.CardWidth
+	row += engine.CardHeight / 2
+	return
+}
+
+// get the coordinates for the score display
+func scoreCoords() (col coord, row coord) {
+	col, row = engine.CollectedPileCoords()
+	col += engine.CardWidth + maxPileStack + 1
+	row += engine.CardHeight / 2
+	return
+}
+
+// get the coordinates of the list of sets found in a puzzle, beside a table of the given size
+func foundSetsCoords(cards int) (col coord, row coord) {
+	col, row = engine.TableCoords(((cards + 2) / 3) * 3)
+	col++
+	return
+}
+
+// get the coordinates of notices shown over the table
+func noticeCoords() (col coord, row coord) {
+	col = 1
+	row = ((engine.CardHeight * 3) / 2) - 2
+	return
+}
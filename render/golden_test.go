@@ -0,0 +1,165 @@
+package render
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+var update = flag.Bool("update", false, "regenerate golden files in testdata")
+
+// compare output to a golden file, or rewrite the file with -update
+func checkGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if actual != string(expected) {
+		t.Errorf("output doesn't match %s (run with -update to accept it)\n"+
+			"got:\n%s\nwant:\n%s", path, actual, expected)
+	}
+}
+
+// a game in a known state to render
+type gameCase struct {
+	name     string
+	seed     int64
+	ticks    int    // ticks to step before any input
+	inputs   string // input characters, where * selects the first set on the table
+	after    int    // ticks to step after the inputs
+	noMotion bool   // whether to turn off effects
+	puzzle   bool   // whether to play a puzzle made from the seed
+}
+
+var gameCases = []gameCase{
+	{name: "dealing", seed: 1, ticks: 3},
+	{name: "revealing", seed: 1, ticks: 74},
+	{name: "dealt", seed: 1, ticks: 200},
+	{name: "selected", seed: 1, ticks: 200, inputs: "ab"},
+	{name: "collecting", seed: 1, ticks: 200, inputs: "*", after: 2},
+	{name: "collecting-reduced-motion", seed: 1, ticks: 200, inputs: "*", after: 2, noMotion: true},
+	{name: "invalid", seed: 2, ticks: 200, inputs: "abc", after: 1},
+	{name: "paused", seed: 1, ticks: 200, inputs: " ", after: 10},
+	{name: "puzzle", seed: 1, ticks: 200, inputs: "**", after: 10, puzzle: true},
+}
+
+// make a game and step it into the state a case describes
+func (c gameCase) game() *engine.Game {
+	g := engine.NewGame(c.seed)
+	if c.puzzle {
+		p, _ := engine.NewPuzzle("test", c.seed, engine.PuzzleSize, engine.PuzzleSets, engine.DifficultyNormal)
+		g = engine.NewPuzzleGame(p)
+	}
+	g.SetReducedMotion(c.noMotion)
+	for i := 0; i < c.ticks; i++ {
+		g.Step()
+	}
+	for _, input := range c.inputs {
+		if input == '*' {
+			for _, slot := range firstSet(g) {
+				g.Input(rune('a' + slot))
+			}
+		} else {
+			g.Input(input)
+		}
+	}
+	for i := 0; i < c.after; i++ {
+		g.Step()
+	}
+	return g
+}
+
+// find the table positions of the first set on the table
+func firstSet(g *engine.Game) []int {
+	sets := solver.Sets(engine.TableIDs(g.Table()))
+	if len(sets) == 0 {
+		return nil
+	}
+	return sets[0][:]
+}
+
+func TestGameGolden(t *testing.T) {
+	for _, c := range gameCases {
+		t.Run(c.name, func(t *testing.T) {
+			f := Game(c.game(), DefaultTheme)
+			checkGolden(t, "game-"+c.name, f.Annotated())
+		})
+	}
+}
+
+func TestOutlineGolden(t *testing.T) {
+	b := strings.Builder{}
+	for shrink := 0; shrink <= engine.MaxShrink; shrink++ {
+		for turn := 0; turn < 8; turn++ {
+			fmt.Fprintf(&b, "shrink %d, turn %d:\n%s\n\n", shrink, turn, renderOutline(shrink, turn))
+		}
+	}
+	checkGolden(t, "outlines", b.String())
+}
+
+func TestReplaceGolden(t *testing.T) {
+	tall := NewFrame()
+	tall.Draw("one\ntwo\nthree\nfour", 0, 0, ColorRed, ColorDefault)
+	short := NewFrame()
+	short.Draw("uno", 1, 0, ColorDefault, ColorBlue)
+	b := strings.Builder{}
+	for _, step := range []struct {
+		name     string
+		old, new Frame
+	}{
+		{"first frame", NewFrame(), tall},
+		{"same size", tall, tall},
+		{"fewer lines", tall, short},
+		{"more lines", short, tall},
+	} {
+		fmt.Fprintf(&b, "%s:\n", step.name)
+		for _, line := range strings.SplitAfter(step.new.Replace(step.old), "\n") {
+			if line != "" {
+				fmt.Fprintf(&b, "%s\n", strconv.Quote(line))
+			}
+		}
+		b.WriteString("\n")
+	}
+	checkGolden(t, "replace", b.String())
+}
+
+// make a small frame with colors, a background, card symbols and characters that need escaping
+func snapshotFrame() Frame {
+	f := NewFrame()
+	f.Draw("Score: <a & b> \"q\" 'x'", 0, 0, ColorWhite, ColorDefault)
+	f.Draw("╭───╮\n│ ◑ │\n╰───╯", 1, 1, ColorWhite, ColorDefault)
+	f.Draw("◑", 3, 2, ColorRed, ColorDefault)
+	f.Draw("A", 6, 2, ColorDarkGray, ColorBlue)
+	return f
+}
+
+func TestSnapshotGolden(t *testing.T) {
+	f := snapshotFrame()
+	checkGolden(t, "snapshot-annotated", f.Annotated())
+	checkGolden(t, "snapshot-html", f.HTML())
+	checkGolden(t, "snapshot-svg", f.SVG())
+}
+
+func TestSnapshotEscaping(t *testing.T) {
+	f := snapshotFrame()
+	for format, snapshot := range map[string]string{"HTML": f.HTML(), "SVG": f.SVG()} {
+		if strings.Contains(snapshot, "<a &") || !strings.Contains(snapshot, "&lt;a &amp; b&gt;") {
+			t.Errorf("%s snapshot doesn't escape markup:\n%s", format, snapshot)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// the size of a character cell in SVG snapshots, in pixels
+const (
+	svgCellWidth  = 10
+	svgCellHeight = 20
+	svgFontSize   = 16
+	svgBaseline   = 15 // the offset of the text baseline from the top of a cell
+)
+
+// HTML renders the frame as a self-contained HTML document with styled spans.
+// Characters outside ASCII are each given a box one character wide so box drawing
+// and card symbols keep the same grid as the terminal even in fallback fonts.
+func (f *Frame) HTML() string {
+	b := strings.Builder{}
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>go81</title>\n<style>\n")
+	fmt.Fprintf(&b, "pre.go81 { display: inline-block; margin: 0; padding: 1ch; "+
+		"font-family: monospace; line-height: 1.25; color: %s; background: %s; }\n",
+		hexColor(DefaultForeground, DefaultForeground), hexColor(DefaultBackground, DefaultBackground))
+	b.WriteString("pre.go81 i { display: inline-block; width: 1ch; font-style: normal; text-align: center; }\n")
+	b.WriteString("</style>\n</head>\n<body>\n<pre class=\"go81\">")
+	_, rows := f.Size()
+	for row := 0; row < rows; row++ {
+		for _, run := range f.runs(row) {
+			text := htmlCells(run.text)
+			if run.color == defaultCellColor {
+				b.WriteString(text)
+				continue
+			}
+			style := "color: " + hexColor(run.color.fg, DefaultForeground)
+			if run.color.bg != ColorDefault {
+				style += "; background: " + hexColor(run.color.bg, DefaultBackground)
+			}
+			fmt.Fprintf(&b, "<span style=\"%s\">%s</span>", style, text)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// SVG renders the frame as a self-contained SVG image with one text element for each run of color.
+func (f *Frame) SVG() string {
+	cols, rows := f.Size()
+	width, height := cols*svgCellWidth, rows*svgCellHeight
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" "+
+		"viewBox=\"0 0 %d %d\" font-family=\"monospace\" font-size=\"%d\">\n",
+		width, height, width, height, svgFontSize)
+	fmt.Fprintf(&b, "<rect width=\"%d\" height=\"%d\" fill=\"%s\"/>\n",
+		width, height, hexColor(DefaultBackground, DefaultBackground))
+	for row := 0; row < rows; row++ {
+		for _, run := range f.runs(row) {
+			x, y := run.col*svgCellWidth, row*svgCellHeight
+			runWidth := len(run.text) * svgCellWidth
+			if run.color.bg != ColorDefault {
+				fmt.Fprintf(&b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n",
+					x, y, runWidth, svgCellHeight, hexColor(run.color.bg, DefaultBackground))
+			}
+			if strings.TrimSpace(string(run.text)) == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" fill=\"%s\" textLength=\"%d\" "+
+				"lengthAdjust=\"spacingAndGlyphs\" xml:space=\"preserve\">%s</text>\n",
+				x, y+svgBaseline, hexColor(run.color.fg, DefaultForeground), runWidth,
+				html.EscapeString(string(run.text)))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// the letters Annotated uses for each console color, in the order of ConsoleColors
+const annotationLetters = "krgybmcwKRGYBMCW"
+
+// Annotated renders the frame as plain text for comparing in tests and reviews.
+// Each line of text is followed by a line of letters giving the foreground color of
+// each character, and by another for the background if any isn't the default:
+// k r g y b m c w for the normal colors, capitals for the light ones, and . for ColorDefault.
+func (f *Frame) Annotated() string {
+	b := strings.Builder{}
+	_, rows := f.Size()
+	for row := 0; row < rows; row++ {
+		width := len(f.lines[row])
+		fg := make([]byte, width)
+		bg := make([]byte, width)
+		hasBackground := false
+		for col := 0; col < width; col++ {
+			_, fgColor, bgColor := f.Cell(col, row)
+			fg[col] = annotationLetter(fgColor)
+			bg[col] = annotationLetter(bgColor)
+			hasBackground = hasBackground || (bgColor != ColorDefault)
+		}
+		fmt.Fprintf(&b, "  |%s\nfg|%s\n", string(f.lines[row]), fg)
+		if hasBackground {
+			fmt.Fprintf(&b, "bg|%s\n", bg)
+		}
+	}
+	return b.String()
+}
+
+// get the letter that stands for a color in annotations
+func annotationLetter(c Color) byte {
+	for i, consoleColor := range ConsoleColors {
+		if c == consoleColor {
+			return annotationLetters[i]
+		}
+	}
+	return '.'
+}
+
+// IMPLEMENTATION *************************************************************
+
+// a span of characters on one line that share the same colors
+type colorRun struct {
+	col   coord
+	text  []rune
+	color cellColor
+}
+
+// split a line into runs of the same color
+func (f *Frame) runs(row coord) []colorRun {
+	if row >= len(f.lines) {
+		return nil
+	}
+	runs := make([]colorRun, 0)
+	for col := range f.lines[row] {
+		r, fg, bg := f.Cell(col, row)
+		c := cellColor{fg, bg}
+		if (len(runs) == 0) || (runs[len(runs)-1].color != c) {
+			runs = append(runs, colorRun{col: col, color: c})
+		}
+		last := &runs[len(runs)-1]
+		last.text = append(last.text, r)
+	}
+	return runs
+}
+
+// escape characters for HTML, boxing any that may not be a single column wide
+func htmlCells(text []rune) string {
+	b := strings.Builder{}
+	for _, r := range text {
+		escaped := html.EscapeString(string(r))
+		if r < 0x80 {
+			b.WriteString(escaped)
+		} else {
+			b.WriteString("<i>" + escaped + "</i>")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package render
+
+import "github.com/jessecrossen/go81/engine"
+
+// A Theme describes the colors and symbols used to draw a game.
+type Theme struct {
+	Outline         Color                    // the outline of a card
+	SelectedOutline Color                    // the outline of a selected card
+	FaceColors      [3]Color                 // card symbols, indexed by the card's color attribute
+	Symbols         [3][3]string             // card symbols, indexed by the card's shape and fill attributes
+	Dot             string                   // the symbol for a dot on a ProSET card
+	DotColors       [engine.ProSetDots]Color // dots on ProSET cards, indexed by bit
+	Letter          Color                    // the letter marking a position on the table
+	SelectedLetter  Color                    // the letter marking the position of a selected card
+	Text            Color                    // ordinary text like the score
+	DimText         Color                    // less important text like the clock
+	Notice          Color                    // notices shown over the table
+	Sparkle         Color                    // sparkles around collected cards
+	Gain            Color                    // increases in score
+	Loss            Color                    // decreases in score
+	FlashForeground Color                    // text color during a screen flash
+	FlashBackground Color                    // background color during a screen flash
+}
+
+// DefaultTheme is the theme the game is normally drawn with.
+var DefaultTheme = Theme{
+	Outline:         ColorLightGray,
+	SelectedOutline: ColorLightCyan,
+	FaceColors:      [3]Color{ColorRed, ColorGreen, ColorBlue},
+	Symbols: [3][3]string{
+		{"△", "◮", "▲"},
+		{"□", "◨", "■"},
+		{"○", "◑", "●"},
+	},
+	Dot: "●",
+	DotColors: [engine.ProSetDots]Color{
+		ColorRed, ColorYellow, ColorGreen, ColorCyan, ColorBlue, ColorMagenta,
+	},
+	Letter:          ColorDarkGray,
+	SelectedLetter:  ColorCyan,
+	Text:            ColorDefault,
+	DimText:         ColorDarkGray,
+	Notice:          ColorLightYellow,
+	Sparkle:         ColorLightYellow,
+	Gain:            ColorLightGreen,
+	Loss:            ColorLightRed,
+	FlashForeground: ColorBlack,
+	FlashBackground: ColorLightYellow,
+}
@@ -0,0 +1,126 @@
+package sim
+
+import (
+	"math/rand"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+// A Bot plays a game by deciding what to input at each tick.
+type Bot interface {
+	// Inputs returns the characters to pass to Game.Input once the table has settled.
+	Inputs(g *engine.Game) []rune
+}
+
+// Bots holds the built-in strategies by name, each made from a seed.
+var Bots = map[string]func(seed int64) Bot{
+	"first":  func(int64) Bot { return firstSetBot{} },
+	"random": func(seed int64) Bot { return &randomSetBot{rand.New(rand.NewSource(seed))} },
+}
+
+// a bot that always takes the first set it finds
+type firstSetBot struct{}
+
+func (firstSetBot) Inputs(g *engine.Game) []rune {
+	sets := tableSets(g.Rules(), g.Table())
+	if len(sets) == 0 {
+		return nil
+	}
+	return slotInputs(sets[0])
+}
+
+// a bot that takes a random set from those on the table
+type randomSetBot struct {
+	random *rand.Rand
+}
+
+func (b *randomSetBot) Inputs(g *engine.Game) []rune {
+	sets := tableSets(g.Rules(), g.Table())
+	if len(sets) == 0 {
+		return nil
+	}
+	return slotInputs(sets[b.random.Intn(len(sets))])
+}
+
+// find the table positions of every set on the table under the given rules,
+// leaving out sets that hold a smaller one, since selecting them would claim
+// the smaller set first when sets can have any number of cards
+func tableSets(rules engine.Rules, table []*engine.Card) [][]int {
+	if _, ok := rules.(engine.ClassicRules); ok {
+		var sets [][]int
+		for _, set := range solver.Sets(engine.TableIDs(table)) {
+			sets = append(sets, []int{set[0], set[1], set[2]})
+		}
+		return sets
+	}
+	var slots []int
+	for slot, card := range table {
+		if card != nil {
+			slots = append(slots, slot)
+		}
+	}
+	sizes := []int{rules.SelectionSize()}
+	if sizes[0] == 0 {
+		sizes = sizes[:0]
+		for size := 1; size <= len(slots); size++ {
+			sizes = append(sizes, size)
+		}
+	}
+	var sets [][]int
+	for _, size := range sizes {
+		eachCombination(slots, size, func(combo []int) {
+			for _, set := range sets {
+				if holds(combo, set) {
+					return
+				}
+			}
+			cards := make([]*engine.Card, len(combo))
+			for i, slot := range combo {
+				cards[i] = table[slot]
+			}
+			if rules.IsSet(cards) {
+				sets = append(sets, append([]int(nil), combo...))
+			}
+		})
+	}
+	return sets
+}
+
+// call a function with every combination of the given size from a list, in order
+func eachCombination(list []int, size int, f func(combo []int)) {
+	combo := make([]int, 0, size)
+	var visit func(start int)
+	visit = func(start int) {
+		if len(combo) == size {
+			f(combo)
+			return
+		}
+		for i := start; i <= len(list)-(size-len(combo)); i++ {
+			combo = append(combo, list[i])
+			visit(i + 1)
+			combo = combo[:len(combo)-1]
+		}
+	}
+	visit(0)
+}
+
+// whether a sorted list holds every item of another sorted list
+func holds(list []int, items []int) bool {
+	i := 0
+	for _, item := range list {
+		if (i < len(items)) && (item == items[i]) {
+			i++
+		}
+	}
+	return i == len(items)
+}
+
+// get the inputs that select cards at the given table positions
+func slotInputs(slots []int) []rune {
+	inputs := make([]rune, len(slots))
+	for i, slot := range slots {
+		inputs[i] = rune('a' + slot)
+	}
+	return inputs
+}
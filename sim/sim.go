@@ -0,0 +1,78 @@
+// Package sim plays games with no display using bots, to gather statistics
+// about how the rules play out.
+package sim
+
+import "github.com/jessecrossen/go81/engine"
+
+// MaxTicks is the longest a simulated game can run before it is abandoned.
+const MaxTicks = 100000
+
+// A TableSample describes the table at one point in a game.
+type TableSample struct {
+	Size int // the number of cards on the table
+	Sets int // the number of sets among them
+}
+
+// A Result describes how one simulated game played out.
+type Result struct {
+	Seed       int64
+	Ticks      int           // the number of ticks until the game ended
+	Sets       int           // the number of sets found
+	Leftover   int           // the number of cards left on the table at the end
+	Finished   bool          // whether the game ended before MaxTicks
+	Tables     []TableSample // every table the bot chose a set from
+	Extensions []int         // the size of every table that was extended for having no set
+}
+
+// Play a game with the given seed and rules using a bot and return what happened.
+func Play(seed int64, rules engine.Rules, bot Bot) Result {
+	r := Result{Seed: seed}
+	g := engine.NewGameWithRules(seed, rules)
+	g.SetReducedMotion(true)
+	g.Subscribe(func(e engine.Event) {
+		switch e := e.(type) {
+		case engine.SetFound:
+			r.Sets++
+		case engine.TableExtended:
+			r.Extensions = append(r.Extensions, e.Size-e.Added)
+		case engine.GameOver:
+			r.Finished = true
+		}
+	})
+	for r.Ticks = 0; (r.Ticks < MaxTicks) && !g.Over(); r.Ticks++ {
+		if settled(g) {
+			table := g.Table()
+			inputs := bot.Inputs(g)
+			if len(inputs) > 0 {
+				r.Tables = append(r.Tables, TableSample{Size: countCards(table), Sets: len(tableSets(rules, table))})
+			}
+			for _, input := range inputs {
+				g.Input(input)
+			}
+		}
+		g.Step()
+	}
+	r.Leftover = countCards(g.Table())
+	return r
+}
+
+// whether every card on the table has been dealt and can be selected
+func settled(g *engine.Game) bool {
+	for _, card := range g.Table() {
+		if (card != nil) && (card.Layer() != engine.LayerDealt) {
+			return false
+		}
+	}
+	return true
+}
+
+// count cards on the table
+func countCards(table []*engine.Card) int {
+	count := 0
+	for _, card := range table {
+		if card != nil {
+			count++
+		}
+	}
+	return count
+}
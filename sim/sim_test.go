@@ -0,0 +1,54 @@
+package sim_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/sim"
+)
+
+func TestBotsFinishEveryVariant(t *testing.T) {
+	for rulesName, rules := range engine.Variants {
+		for botName, newBot := range sim.Bots {
+			t.Run(rulesName+"/"+botName, func(t *testing.T) {
+				for seed := int64(1); seed <= 5; seed++ {
+					r := sim.Play(seed, rules, newBot(seed))
+					if !r.Finished || (r.Sets == 0) {
+						t.Fatalf("seed %d finished %v with %d sets", seed, r.Finished, r.Sets)
+					}
+					if len(r.Tables) != r.Sets {
+						t.Errorf("seed %d sampled %d tables for %d sets", seed, len(r.Tables), r.Sets)
+					}
+					for _, table := range r.Tables {
+						if (table.Sets == 0) || (table.Size == 0) {
+							t.Errorf("seed %d took a set from a table of %d cards with %d sets",
+								seed, table.Size, table.Sets)
+						}
+					}
+					size := rules.SelectionSize()
+					if (size > 0) && (r.Sets*size+r.Leftover != len(rules.NewDeck())) {
+						t.Errorf("seed %d found %d sets of %d and left %d cards of %d",
+							seed, r.Sets, size, r.Leftover, len(rules.NewDeck()))
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPlayIsDeterministic(t *testing.T) {
+	names := make([]string, 0, len(sim.Bots))
+	for name := range sim.Bots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		first := sim.Play(7, engine.ClassicRules{}, sim.Bots[name](7))
+		second := sim.Play(7, engine.ClassicRules{}, sim.Bots[name](7))
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("%s bot played the same seed differently:\n%+v\n%+v", name, first, second)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package sim
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+)
+
+// A Histogram counts how often each value occurs.
+type Histogram struct {
+	counts map[int]int
+	total  int
+	sum    int
+}
+
+// Add one occurrence of a value.
+func (h *Histogram) Add(value int) {
+	if h.counts == nil {
+		h.counts = make(map[int]int)
+	}
+	h.counts[value]++
+	h.total++
+	h.sum += value
+}
+
+// Count returns the number of times a value occurred.
+func (h *Histogram) Count(value int) int {
+	return h.counts[value]
+}
+
+// Total returns the number of values added.
+func (h *Histogram) Total() int {
+	return h.total
+}
+
+// Mean returns the average of the values added, or 0 if there are none.
+func (h *Histogram) Mean() float64 {
+	if h.total == 0 {
+		return 0
+	}
+	return float64(h.sum) / float64(h.total)
+}
+
+// Values returns every distinct value added, in increasing order.
+func (h *Histogram) Values() []int {
+	values := make([]int, 0, len(h.counts))
+	for value := range h.counts {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+	return values
+}
+
+// Stats gathers distributions from many simulated games.
+type Stats struct {
+	Games        int
+	Unfinished   int                // games abandoned after MaxTicks
+	TableSizes   Histogram          // the size of every table a set was taken from or that had none
+	NoSet        Histogram          // the size of every table that had no set
+	SetsPerTable Histogram          // the number of sets on every table
+	SetsBySize   map[int]*Histogram // the number of sets on tables, by table size
+	GameSeconds  Histogram          // the game time each game took, in whole seconds
+	SetsPerGame  Histogram          // the number of sets found in each game
+	Leftovers    Histogram          // the number of cards left on the table when each game ended
+}
+
+// Add the result of one game.
+func (s *Stats) Add(r Result) {
+	s.Games++
+	if !r.Finished {
+		s.Unfinished++
+	}
+	for _, table := range r.Tables {
+		s.addTable(table.Size, table.Sets)
+	}
+	for _, size := range r.Extensions {
+		s.addTable(size, 0)
+		s.NoSet.Add(size)
+	}
+	s.GameSeconds.Add(int((time.Duration(r.Ticks) * engine.TickDuration) / time.Second))
+	s.SetsPerGame.Add(r.Sets)
+	s.Leftovers.Add(r.Leftover)
+}
+
+// WriteText writes a human-readable report of the distributions.
+func (s *Stats) WriteText(w io.Writer) error {
+	p := &errWriter{w: w}
+	p.printf("games: %d (%d unfinished)\n", s.Games, s.Unfinished)
+	p.printf("\ntables\n  %6s %8s %8s %9s %10s\n", "size", "count", "no set", "no set %", "mean sets")
+	for _, size := range s.TableSizes.Values() {
+		count := s.TableSizes.Count(size)
+		p.printf("  %6d %8d %8d %8.2f%% %10.2f\n", size, count, s.NoSet.Count(size),
+			percent(s.NoSet.Count(size), count), s.SetsBySize[size].Mean())
+	}
+	s.writeHistogram(p, "sets per table", "sets", &s.SetsPerTable)
+	s.writeHistogram(p, "game length", "seconds", &s.GameSeconds)
+	s.writeHistogram(p, "sets found per game", "sets", &s.SetsPerGame)
+	s.writeHistogram(p, "cards left at the end", "cards", &s.Leftovers)
+	return p.err
+}
+
+// WriteCSV writes the distributions as rows of metric, value and count.
+func (s *Stats) WriteCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	c.Write([]string{"metric", "value", "count"})
+	write := func(metric string, h *Histogram) {
+		for _, value := range h.Values() {
+			c.Write([]string{metric, strconv.Itoa(value), strconv.Itoa(h.Count(value))})
+		}
+	}
+	write("table_size", &s.TableSizes)
+	write("table_size_no_set", &s.NoSet)
+	write("sets_per_table", &s.SetsPerTable)
+	for _, size := range s.TableSizes.Values() {
+		write(fmt.Sprintf("sets_on_%d_card_table", size), s.SetsBySize[size])
+	}
+	write("game_seconds", &s.GameSeconds)
+	write("sets_per_game", &s.SetsPerGame)
+	write("cards_left", &s.Leftovers)
+	c.Flush()
+	return c.Error()
+}
+
+// add a table of the given size with the given number of sets
+func (s *Stats) addTable(size int, sets int) {
+	if s.SetsBySize == nil {
+		s.SetsBySize = make(map[int]*Histogram)
+	}
+	if s.SetsBySize[size] == nil {
+		s.SetsBySize[size] = &Histogram{}
+	}
+	s.TableSizes.Add(size)
+	s.SetsPerTable.Add(sets)
+	s.SetsBySize[size].Add(sets)
+}
+
+// write one distribution as a table with a mean
+func (s *Stats) writeHistogram(p *errWriter, title string, unit string, h *Histogram) {
+	p.printf("\n%s (mean %.2f)\n  %7s %8s %8s\n", title, h.Mean(), unit, "count", "percent")
+	for _, value := range h.Values() {
+		p.printf("  %7d %8d %7.2f%%\n", value, h.Count(value), percent(h.Count(value), h.Total()))
+	}
+}
+
+func percent(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(count) / float64(total)
+}
+
+// a writer that remembers the first error so reports can be written without checking each line
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *errWriter) printf(format string, args ...interface{}) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
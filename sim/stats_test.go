@@ -0,0 +1,78 @@
+package sim_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/sim"
+)
+
+func TestHistogram(t *testing.T) {
+	h := sim.Histogram{}
+	if (h.Total() != 0) || (h.Mean() != 0) || (len(h.Values()) != 0) {
+		t.Fatalf("empty histogram has total %d, mean %v, values %v", h.Total(), h.Mean(), h.Values())
+	}
+	for _, value := range []int{3, 1, 3, 5} {
+		h.Add(value)
+	}
+	if (h.Total() != 4) || (h.Mean() != 3) || (h.Count(3) != 2) || (h.Count(2) != 0) {
+		t.Errorf("got total %d, mean %v, count of 3 %d, count of 2 %d, want 4, 3, 2, 0",
+			h.Total(), h.Mean(), h.Count(3), h.Count(2))
+	}
+	if values := h.Values(); !reflect.DeepEqual(values, []int{1, 3, 5}) {
+		t.Errorf("got values %v, want [1 3 5]", values)
+	}
+}
+
+// the ticks in the given number of seconds
+func ticks(seconds int) int {
+	return int(time.Duration(seconds) * time.Second / engine.TickDuration)
+}
+
+func TestStats(t *testing.T) {
+	s := sim.Stats{}
+	s.Add(sim.Result{
+		Ticks: ticks(60), Sets: 2, Leftover: 0, Finished: true,
+		Tables:     []sim.TableSample{{Size: 12, Sets: 1}, {Size: 15, Sets: 2}},
+		Extensions: []int{12},
+	})
+	s.Add(sim.Result{Ticks: ticks(90), Sets: 1, Leftover: 6, Tables: []sim.TableSample{{Size: 12, Sets: 3}}})
+	if (s.Games != 2) || (s.Unfinished != 1) {
+		t.Errorf("counted %d games with %d unfinished, want 2 with 1", s.Games, s.Unfinished)
+	}
+	if (s.TableSizes.Count(12) != 3) || (s.TableSizes.Count(15) != 1) || (s.NoSet.Count(12) != 1) {
+		t.Errorf("counted tables of 12 and 15 as %d and %d with %d of 12 having no set, want 3, 1 and 1",
+			s.TableSizes.Count(12), s.TableSizes.Count(15), s.NoSet.Count(12))
+	}
+	if mean := s.SetsBySize[12].Mean(); mean != 4.0/3.0 {
+		t.Errorf("got mean sets on tables of 12 %v, want %v", mean, 4.0/3.0)
+	}
+	if !reflect.DeepEqual(s.GameSeconds.Values(), []int{60, 90}) {
+		t.Errorf("got game seconds %v, want [60 90]", s.GameSeconds.Values())
+	}
+	if (s.SetsPerGame.Mean() != 1.5) || (s.Leftovers.Mean() != 3) {
+		t.Errorf("got mean sets per game %v and leftovers %v, want 1.5 and 3",
+			s.SetsPerGame.Mean(), s.Leftovers.Mean())
+	}
+	var csv bytes.Buffer
+	if err := s.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range []string{"metric,value,count", "table_size,12,3", "table_size_no_set,12,1",
+		"sets_on_15_card_table,2,1", "game_seconds,90,1", "cards_left,6,1"} {
+		if !strings.Contains(csv.String(), row+"\n") {
+			t.Errorf("CSV report is missing %q:\n%s", row, csv.String())
+		}
+	}
+	var text bytes.Buffer
+	if err := s.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(text.String(), "games: 2 (1 unfinished)\n") {
+		t.Errorf("text report starts wrong:\n%s", text.String())
+	}
+}
@@ -0,0 +1,182 @@
+// Package solver finds sets quickly using the base-3 encoding of card ids.
+//
+// Each card id from 0 to 80 is a four-digit base-3 number with one digit for
+// each attribute. Three cards form a set exactly when each digit sums to a
+// multiple of 3, so any two cards determine the only card that completes
+// them. That lets every set on a table be found from its pairs in O(n²)
+// instead of checking every triple. The same goes for Ultras, which are four
+// cards that split into two pairs completed by the same card.
+package solver
+
+import "sort"
+
+// DeckSize is the number of distinct card ids.
+const DeckSize = 81
+
+// Attributes is the number of base-3 digits in a card id.
+const Attributes = 4
+
+// thirds[a*DeckSize+b] holds the id of the card that completes a set with a and b
+var thirds = buildThirds()
+
+// Third returns the id of the card that completes a set with the cards a and b.
+func Third(a, b int) int {
+	return int(thirds[a*DeckSize+b])
+}
+
+// IsSet returns whether three card ids form a set.
+func IsSet(a, b, c int) bool {
+	return Third(a, b) == c
+}
+
+// Sets returns the positions of every set among the given card ids,
+// where negative ids mark empty positions. Each set is listed with its
+// positions in increasing order, and sets are sorted by those positions.
+func Sets(ids []int) [][3]int {
+	sets := make([][3]int, 0)
+	eachSet(ids, func(set [3]int) bool {
+		sets = append(sets, set)
+		return true
+	})
+	return sets
+}
+
+// Count returns the number of sets among the given card ids.
+func Count(ids []int) int {
+	count := 0
+	eachSet(ids, func([3]int) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// HasSet returns whether there is any set among the given card ids.
+func HasSet(ids []int) bool {
+	found := false
+	eachSet(ids, func([3]int) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// IsUltra returns whether four card ids split into two pairs that the same
+// card would complete to a set.
+func IsUltra(a, b, c, d int) bool {
+	return (Third(a, b) == Third(c, d)) ||
+		(Third(a, c) == Third(b, d)) ||
+		(Third(a, d) == Third(b, c))
+}
+
+// Ultras returns the positions of every four cards among the given card ids
+// that form an Ultra, where negative ids mark empty positions. Each Ultra is
+// listed with its positions in increasing order, and Ultras are sorted by
+// those positions.
+func Ultras(ids []int) [][4]int {
+	ultras := make([][4]int, 0)
+	for _, pairs := range pairsByThird(ids) {
+		// two different pairs with the same third card can't share a card
+		for i := 0; i < len(pairs); i++ {
+			for j := i + 1; j < len(pairs); j++ {
+				ultra := [4]int{pairs[i][0], pairs[i][1], pairs[j][0], pairs[j][1]}
+				sort.Ints(ultra[:])
+				ultras = append(ultras, ultra)
+			}
+		}
+	}
+	sort.Slice(ultras, func(i, j int) bool {
+		for k := range ultras[i] {
+			if ultras[i][k] != ultras[j][k] {
+				return ultras[i][k] < ultras[j][k]
+			}
+		}
+		return false
+	})
+	return ultras
+}
+
+// HasUltra returns whether there is any Ultra among the given card ids.
+func HasUltra(ids []int) bool {
+	for _, pairs := range pairsByThird(ids) {
+		if len(pairs) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// IMPLEMENTATION *************************************************************
+
+// call a function with each set in order until it returns false
+func eachSet(ids []int, f func([3]int) bool) {
+	// map each id to its position, so the third card of a pair can be found directly
+	var positions [DeckSize]int
+	for i := range positions {
+		positions[i] = -1
+	}
+	for i, id := range ids {
+		if (id >= 0) && (id < DeckSize) {
+			positions[id] = i
+		}
+	}
+	for i := 0; i < len(ids); i++ {
+		if (ids[i] < 0) || (ids[i] >= DeckSize) {
+			continue
+		}
+		for j := i + 1; j < len(ids); j++ {
+			if (ids[j] < 0) || (ids[j] >= DeckSize) || (ids[j] == ids[i]) {
+				continue
+			}
+			// only take the third card when it comes later, so each set is found once
+			k := positions[Third(ids[i], ids[j])]
+			if k > j {
+				if !f([3]int{i, j, k}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// group the positions of every pair of cards by the card that completes them
+func pairsByThird(ids []int) [DeckSize][][2]int {
+	var pairs [DeckSize][][2]int
+	for i := 0; i < len(ids); i++ {
+		if (ids[i] < 0) || (ids[i] >= DeckSize) {
+			continue
+		}
+		for j := i + 1; j < len(ids); j++ {
+			if (ids[j] < 0) || (ids[j] >= DeckSize) || (ids[j] == ids[i]) {
+				continue
+			}
+			third := Third(ids[i], ids[j])
+			pairs[third] = append(pairs[third], [2]int{i, j})
+		}
+	}
+	return pairs
+}
+
+// compute the third card for every pair of cards
+func buildThirds() []uint8 {
+	table := make([]uint8, DeckSize*DeckSize)
+	for a := 0; a < DeckSize; a++ {
+		for b := 0; b < DeckSize; b++ {
+			table[a*DeckSize+b] = uint8(third(a, b))
+		}
+	}
+	return table
+}
+
+// find the third card digit by digit, where each digit of the result makes the sum a multiple of 3
+func third(a, b int) int {
+	c := 0
+	place := 1
+	for digit := 0; digit < Attributes; digit++ {
+		da, db := a%3, b%3
+		c += ((6 - da - db) % 3) * place
+		a, b = a/3, b/3
+		place *= 3
+	}
+	return c
+}
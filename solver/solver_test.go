@@ -0,0 +1,134 @@
+package solver_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/jessecrossen/go81/engine"
+	"github.com/jessecrossen/go81/solver"
+)
+
+// make a table of distinct random cards
+func randomTable(random *rand.Rand, size int) []int {
+	return random.Perm(solver.DeckSize)[:size]
+}
+
+// find sets the slow way, by checking every triple with engine.AreSet
+func bruteForceSets(ids []int) [][3]int {
+	cards := make([]engine.Card, len(ids))
+	for i, id := range ids {
+		cards[i] = engine.NewCard(id)
+	}
+	sets := make([][3]int, 0)
+	for i := 0; i < len(cards); i++ {
+		for j := i + 1; j < len(cards); j++ {
+			for k := j + 1; k < len(cards); k++ {
+				if engine.AreSet(&cards[i], &cards[j], &cards[k]) {
+					sets = append(sets, [3]int{i, j, k})
+				}
+			}
+		}
+	}
+	return sets
+}
+
+func TestThirdMatchesAreSet(t *testing.T) {
+	for a := 0; a < solver.DeckSize; a++ {
+		for b := 0; b < solver.DeckSize; b++ {
+			if a == b {
+				continue
+			}
+			ca, cb, cc := engine.NewCard(a), engine.NewCard(b), engine.NewCard(solver.Third(a, b))
+			if !engine.AreSet(&ca, &cb, &cc) {
+				t.Fatalf("Third(%d, %d) = %d, which isn't a set", a, b, solver.Third(a, b))
+			}
+		}
+	}
+}
+
+func TestSetsMatchBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		ids := randomTable(random, 3+random.Intn(19))
+		expected := bruteForceSets(ids)
+		if actual := solver.Sets(ids); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Sets(%v) = %v, want %v", ids, actual, expected)
+		}
+		if actual := solver.Count(ids); actual != len(expected) {
+			t.Fatalf("Count(%v) = %d, want %d", ids, actual, len(expected))
+		}
+		if actual := solver.HasSet(ids); actual != (len(expected) > 0) {
+			t.Fatalf("HasSet(%v) = %v, want %v", ids, actual, len(expected) > 0)
+		}
+	}
+}
+
+// find Ultras the slow way, by checking every way to split every four cards into pairs
+func bruteForceUltras(ids []int) [][4]int {
+	cards := make([]engine.Card, len(ids))
+	for i, id := range ids {
+		cards[i] = engine.NewCard(id)
+	}
+	completes := func(a, b, c, d int) bool {
+		for id := 0; id < solver.DeckSize; id++ {
+			third := engine.NewCard(id)
+			if engine.AreSet(&cards[a], &cards[b], &third) {
+				return engine.AreSet(&cards[c], &cards[d], &third)
+			}
+		}
+		return false
+	}
+	ultras := make([][4]int, 0)
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			for k := j + 1; k < len(ids); k++ {
+				for l := k + 1; l < len(ids); l++ {
+					if completes(i, j, k, l) || completes(i, k, j, l) || completes(i, l, j, k) {
+						ultras = append(ultras, [4]int{i, j, k, l})
+					}
+				}
+			}
+		}
+	}
+	return ultras
+}
+
+func TestUltrasMatchBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		ids := randomTable(random, 4+random.Intn(9))
+		expected := bruteForceUltras(ids)
+		if actual := solver.Ultras(ids); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Ultras(%v) = %v, want %v", ids, actual, expected)
+		}
+		if actual := solver.HasUltra(ids); actual != (len(expected) > 0) {
+			t.Fatalf("HasUltra(%v) = %v, want %v", ids, actual, len(expected) > 0)
+		}
+	}
+}
+
+func TestSetsSkipsEmptyPositions(t *testing.T) {
+	// 0, 1 and 2 differ only in count
+	ids := []int{0, -1, 1, -1, 2}
+	if actual, expected := solver.Sets(ids), [][3]int{{0, 2, 4}}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Sets(%v) = %v, want %v", ids, actual, expected)
+	}
+}
+
+func benchmarkTable(b *testing.B, size int, find func([]int) [][3]int) {
+	random := rand.New(rand.NewSource(1))
+	tables := make([][]int, 100)
+	for i := range tables {
+		tables[i] = randomTable(random, size)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		find(tables[i%len(tables)])
+	}
+}
+
+func BenchmarkSets12(b *testing.B)       { benchmarkTable(b, 12, solver.Sets) }
+func BenchmarkSets21(b *testing.B)       { benchmarkTable(b, 21, solver.Sets) }
+func BenchmarkBruteForce12(b *testing.B) { benchmarkTable(b, 12, bruteForceSets) }
+func BenchmarkBruteForce21(b *testing.B) { benchmarkTable(b, 21, bruteForceSets) }
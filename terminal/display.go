@@ -0,0 +1,31 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jessecrossen/go81/render"
+)
+
+// NewDisplay returns a channel which accepts frames and sends them to the terminal.
+func NewDisplay() chan<- render.Frame {
+	display, _ := NewDisplayTo(os.Stdout)
+	return display
+}
+
+// NewDisplayTo returns a channel which accepts frames and writes them to w,
+// along with a channel that is closed once the display is closed and every frame is written.
+func NewDisplayTo(w io.Writer) (chan<- render.Frame, <-chan struct{}) {
+	display := make(chan render.Frame)
+	done := make(chan struct{})
+	go func() {
+		lastFrame := render.NewFrame()
+		for thisFrame := range display {
+			fmt.Fprint(w, thisFrame.Replace(lastFrame))
+			lastFrame = thisFrame
+		}
+		close(done)
+	}()
+	return display, done
+}
@@ -0,0 +1,3 @@
+// Package terminal connects a game to an interactive terminal, putting it in
+// raw mode, reading typed characters and displaying rendered frames.
+package terminal
@@ -0,0 +1,36 @@
+package terminal
+
+import (
+	"bufio"
+	"os"
+	"time"
+)
+
+// NewInput returns a channel which receives characters typed on standard input.
+func NewInput() <-chan rune {
+	input := make(chan rune)
+	go func() {
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			c, _, err := reader.ReadRune()
+			if err == nil {
+				input <- c
+			}
+		}
+	}()
+	return input
+}
+
+// NewTimer returns a channel which receives an increasing count at the given interval.
+func NewTimer(interval time.Duration) <-chan int {
+	times := make(chan int)
+	go func() {
+		counter := 0
+		for {
+			times <- counter
+			counter++
+			time.Sleep(interval)
+		}
+	}()
+	return times
+}
@@ -0,0 +1,25 @@
+package terminal
+
+import "os/exec"
+
+// EnableRawMode makes input available a character at a time without echoing it.
+func EnableRawMode() {
+	disableLineBuffering()
+	disableEcho()
+}
+
+// Restore returns the terminal to normal line-buffered input with echo.
+func Restore() {
+	enableEcho()
+}
+
+// from: https://stackoverflow.com/a/17278730/745831
+func disableLineBuffering() {
+	exec.Command("stty", "-F", "/dev/tty", "cbreak", "min", "1").Run()
+}
+func disableEcho() {
+	exec.Command("stty", "-F", "/dev/tty", "-echo").Run()
+}
+func enableEcho() {
+	exec.Command("stty", "-F", "/dev/tty", "echo").Run()
+}